@@ -0,0 +1,169 @@
+package aurora
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type OverflowPolicy int
+
+const (
+	OverflowBlock OverflowPolicy = iota
+	OverflowDropNewest
+	OverflowDropOldest
+)
+
+type AsyncSink struct {
+	sink    Sink
+	policy  OverflowPolicy
+	dropped atomic.Uint64
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	idle     *sync.Cond
+	buf      []Record
+	head     int
+	count    int
+	busy     bool
+	closed   bool
+	done     chan struct{}
+}
+
+func NewAsyncSink(s Sink, size int, policy OverflowPolicy) *AsyncSink {
+	if size <= 0 {
+		size = 1024
+	}
+
+	a := &AsyncSink{
+		sink:   s,
+		policy: policy,
+		buf:    make([]Record, size),
+		done:   make(chan struct{}),
+	}
+	a.notEmpty = sync.NewCond(&a.mu)
+	a.notFull = sync.NewCond(&a.mu)
+	a.idle = sync.NewCond(&a.mu)
+
+	go a.run()
+	return a
+}
+
+func (a *AsyncSink) Enabled(level Level) bool {
+	return a.sink.Enabled(level)
+}
+
+func (a *AsyncSink) Write(r *Record) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.closed {
+		return a.sink.Write(r)
+	}
+
+	for a.count == len(a.buf) {
+		switch a.policy {
+		case OverflowDropNewest:
+			a.dropped.Add(1)
+			return nil
+		case OverflowDropOldest:
+			a.buf[a.head] = Record{}
+			a.head = (a.head + 1) % len(a.buf)
+			a.count--
+			a.dropped.Add(1)
+		default:
+			a.notFull.Wait()
+			if a.closed {
+				return a.sink.Write(r)
+			}
+		}
+	}
+
+	rec := *r
+	rec.Fields = make([]Field, len(r.Fields))
+	for i, f := range r.Fields {
+		rec.Fields[i] = f.Snapshot()
+	}
+	a.buf[(a.head+a.count)%len(a.buf)] = rec
+	a.count++
+	a.notEmpty.Signal()
+	return nil
+}
+
+func (a *AsyncSink) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+func (a *AsyncSink) Len() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.count
+}
+
+func (a *AsyncSink) Flush() error {
+	a.mu.Lock()
+	for a.count > 0 || a.busy {
+		a.idle.Wait()
+	}
+	a.mu.Unlock()
+
+	if f, ok := a.sink.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func (a *AsyncSink) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	a.notEmpty.Broadcast()
+	a.notFull.Broadcast()
+	a.mu.Unlock()
+
+	<-a.done
+
+	if c, ok := a.sink.(Closer); ok {
+		return c.Close()
+	}
+	if f, ok := a.sink.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func (a *AsyncSink) run() {
+	defer close(a.done)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for {
+		for a.count == 0 && !a.closed {
+			a.notEmpty.Wait()
+		}
+		if a.count == 0 && a.closed {
+			a.idle.Broadcast()
+			return
+		}
+
+		rec := a.buf[a.head]
+		a.buf[a.head] = Record{}
+		a.head = (a.head + 1) % len(a.buf)
+		a.count--
+		a.busy = true
+		a.notFull.Signal()
+		a.mu.Unlock()
+
+		a.sink.Write(&rec)
+
+		a.mu.Lock()
+		a.busy = false
+		if a.count == 0 {
+			a.idle.Broadcast()
+		}
+	}
+}
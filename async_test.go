@@ -0,0 +1,215 @@
+package aurora
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type gateSink struct {
+	gate chan struct{}
+
+	mu      sync.Mutex
+	lines   []string
+	flushes int
+	closed  bool
+}
+
+func newGateSink() *gateSink {
+	return &gateSink{gate: make(chan struct{})}
+}
+
+func (s *gateSink) Enabled(Level) bool { return true }
+
+func (s *gateSink) Write(r *Record) error {
+	<-s.gate
+	line := format.JSON{TimeKey: "-", LevelKey: "-"}.Format(nil, r)
+	s.mu.Lock()
+	s.lines = append(s.lines, strings.TrimSuffix(string(line), "\n"))
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *gateSink) Flush() error {
+	s.mu.Lock()
+	s.flushes++
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *gateSink) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *gateSink) messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var msgs []string
+	for _, line := range s.lines {
+		msg := strings.TrimPrefix(line, `{"message":"`)
+		msgs = append(msgs, msg[:strings.IndexByte(msg, '"')])
+	}
+	return msgs
+}
+
+func asyncRecord(msg string, fields ...Field) *Record {
+	return &Record{Time: time.Now(), Level: InfoLevel.format(nil), Message: msg, Fields: fields}
+}
+
+// fillAsync writes m1 and waits until the writer goroutine holds it, blocked
+// on the sink's gate, so that the queue is empty for the caller to fill.
+func fillAsync(t *testing.T, a *AsyncSink) {
+	t.Helper()
+	a.Write(asyncRecord("m1"))
+	deadline := time.Now().Add(5 * time.Second)
+	for a.Len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("writer goroutine never took the first entry")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAsyncSinkDropPolicies(t *testing.T) {
+	tests := []struct {
+		policy OverflowPolicy
+		want   string
+	}{
+		{OverflowDropNewest, "m1 m2"},
+		{OverflowDropOldest, "m1 m4"},
+	}
+	for _, tt := range tests {
+		sink := newGateSink()
+		a := NewAsyncSink(sink, 1, tt.policy)
+		fillAsync(t, a)
+		for _, msg := range []string{"m2", "m3", "m4"} {
+			a.Write(asyncRecord(msg))
+		}
+		if got := a.Dropped(); got != 2 {
+			t.Errorf("policy %d: Dropped() = %d, want 2", tt.policy, got)
+		}
+
+		close(sink.gate)
+		if err := a.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(sink.messages(), " "); got != tt.want {
+			t.Errorf("policy %d: wrote %q, want %q", tt.policy, got, tt.want)
+		}
+		a.Close()
+	}
+}
+
+func TestAsyncSinkBlock(t *testing.T) {
+	sink := newGateSink()
+	a := NewAsyncSink(sink, 1, OverflowBlock)
+	fillAsync(t, a)
+	a.Write(asyncRecord("m2"))
+
+	done := make(chan struct{})
+	go func() {
+		a.Write(asyncRecord("m3"))
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("Write returned while the queue was full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(sink.gate)
+	<-done
+	if err := a.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(sink.messages(), " "); got != "m1 m2 m3" {
+		t.Fatalf("wrote %q, want m1 m2 m3", got)
+	}
+	if got := a.Dropped(); got != 0 {
+		t.Fatalf("Dropped() = %d, want 0", got)
+	}
+	a.Close()
+}
+
+func TestAsyncSinkFlushAndClose(t *testing.T) {
+	sink := newGateSink()
+	close(sink.gate)
+	a := NewAsyncSink(sink, 16, OverflowBlock)
+
+	for i := 0; i < 10; i++ {
+		a.Write(asyncRecord("m" + strconv.Itoa(i)))
+	}
+	if err := a.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(sink.messages()); n != 10 || a.Len() != 0 {
+		t.Fatalf("after Flush: %d written, %d queued", n, a.Len())
+	}
+	if sink.flushes != 1 {
+		t.Fatalf("sink flushed %d times, want 1", sink.flushes)
+	}
+
+	a.Write(asyncRecord("last"))
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !sink.closed {
+		t.Fatal("Close did not close the wrapped sink")
+	}
+	msgs := sink.messages()
+	if msgs[len(msgs)-1] != "last" {
+		t.Fatalf("Close did not drain the queue: %v", msgs)
+	}
+
+	a.Write(asyncRecord("after"))
+	if msgs := sink.messages(); msgs[len(msgs)-1] != "after" {
+		t.Fatalf("Write after Close was not written through: %v", msgs)
+	}
+	if err := a.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+}
+
+type counter struct{ n int }
+
+func (c *counter) String() string { return "n=" + strconv.Itoa(c.n) }
+
+func TestAsyncSinkSnapshotsValues(t *testing.T) {
+	sink := newGateSink()
+	a := NewAsyncSink(sink, 4, OverflowBlock)
+
+	m := map[string]int{"n": 1}
+	s := []int{1}
+	p := &struct{ N int }{1}
+	c := &counter{1}
+	a.Write(asyncRecord("m1",
+		format.Any("map", m),
+		format.Any("slice", s),
+		format.Any("ptr", p),
+		format.Any("stringer", c),
+		format.Group("group", format.Any("map", m)),
+	))
+
+	m["n"] = 2
+	s[0] = 2
+	p.N = 2
+	c.n = 2
+
+	close(sink.gate)
+	if err := a.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	a.Close()
+
+	want := `{"message":"m1","map":{"n":1},"slice":[1],"ptr":{"N":1},"stringer":"n=1","group":{"map":{"n":1}}}`
+	if got := sink.lines[0]; got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/Summaw/aurora/pkg/banner"
 	"github.com/Summaw/aurora/pkg/color"
+	"github.com/Summaw/aurora/pkg/format"
+	"github.com/Summaw/aurora/pkg/rotate"
 	"github.com/Summaw/aurora/pkg/style"
 )
 
@@ -26,7 +28,7 @@ func New(opts ...Option) *Logger {
 	cfg := &Config{
 		Output:      os.Stdout,
 		Level:       InfoLevel,
-		TimeFormat:  "15:04:05.000",
+		TimeFormat:  format.DefaultTimeFormat,
 		ShowCaller:  false,
 		CallerDepth: 4,
 	}
@@ -34,10 +36,15 @@ func New(opts ...Option) *Logger {
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	cfg.refreshOutput()
+	cfg.setLevel(cfg.Level)
+	cfg.setLevelRules(cfg.LevelRules)
+	cfg.setSinks(cfg.Sinks)
+	cfg.setHooks(cfg.Hooks)
 
 	return &Logger{
 		config: cfg,
-		mu:     sync.Mutex{},
+		mu:     &sync.Mutex{},
 	}
 }
 
@@ -89,6 +96,14 @@ func Progress(label string, total int) *style.ProgressBar {
 	return style.NewProgressBar(label, total)
 }
 
+func RotatingFile(filename string, opts ...rotate.Option) (*rotate.File, error) {
+	return rotate.New(filename, opts...)
+}
+
+func Log(level Level, msg string) *Entry {
+	return Default().Log(level, msg)
+}
+
 func Trace(msg string) *Entry {
 	return Default().Trace(msg)
 }
@@ -0,0 +1,301 @@
+package auroratest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Summaw/aurora"
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type Entry struct {
+	Time    time.Time
+	Level   aurora.Level
+	Message string
+	Fields  []aurora.Field
+	Caller  string
+	Name    string
+}
+
+func (e Entry) Field(key string) (any, bool) {
+	for _, f := range e.Fields {
+		if f.Key == key {
+			return f.Any(), true
+		}
+	}
+	return nil, false
+}
+
+func (e Entry) String() string {
+	var b strings.Builder
+	b.WriteString(e.Level.String())
+	b.WriteString(" ")
+	b.WriteString(e.Message)
+	for _, f := range e.Fields {
+		b.WriteString(" ")
+		b.WriteString(f.Key)
+		b.WriteString("=")
+		b.Write(f.AppendText(nil))
+	}
+	return b.String()
+}
+
+type Option func(*Recorder)
+
+func WithLevel(level aurora.Level) Option {
+	return func(r *Recorder) {
+		r.level = level
+	}
+}
+
+func FailOnError() Option {
+	return func(r *Recorder) {
+		r.failOnError = true
+	}
+}
+
+func WithTBLog() Option {
+	return func(r *Recorder) {
+		r.tbLog = true
+	}
+}
+
+type Recorder struct {
+	t           testing.TB
+	level       aurora.Level
+	failOnError bool
+	tbLog       bool
+
+	mu       sync.Mutex
+	entries  []Entry
+	matched  []bool
+	exitCode int
+	exited   bool
+}
+
+func NewRecorder(t testing.TB, opts ...Option) *Recorder {
+	r := &Recorder{t: t}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	t.Cleanup(r.check)
+	return r
+}
+
+func NewLogger(t testing.TB, opts ...Option) (*aurora.Logger, *Recorder) {
+	r := NewRecorder(t, opts...)
+
+	sinks := []aurora.Sink{r}
+	if r.tbLog {
+		sinks = append(sinks, NewTBSink(t, r.level, nil))
+	}
+
+	log := aurora.New(
+		aurora.WithLevel(r.level),
+		aurora.WithSinks(sinks...),
+		aurora.WithExitFunc(r.exit),
+		aurora.WithNoExitHandlers(true),
+	)
+	return log, r
+}
+
+func (r *Recorder) Enabled(level aurora.Level) bool {
+	return level >= r.level
+}
+
+func (r *Recorder) Write(rec *aurora.Record) error {
+	e := Entry{
+		Time:    rec.Time,
+		Level:   aurora.Level(rec.Level.Value),
+		Message: rec.Message,
+		Fields:  append([]aurora.Field(nil), rec.Fields...),
+		Caller:  rec.Caller,
+		Name:    rec.Name,
+	}
+
+	r.mu.Lock()
+	r.entries = append(r.entries, e)
+	r.matched = append(r.matched, false)
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *Recorder) exit(code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exitCode = code
+	r.exited = true
+}
+
+func (r *Recorder) Exited() (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.exitCode, r.exited
+}
+
+func (r *Recorder) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Entry(nil), r.entries...)
+}
+
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+	r.matched = nil
+}
+
+func (r *Recorder) Find(level aurora.Level, msg string, fields ...aurora.Field) (Entry, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.find(level, msg, fields)
+	if i < 0 {
+		return Entry{}, false
+	}
+	r.matched[i] = true
+	return r.entries[i], true
+}
+
+func (r *Recorder) RequireEntry(level aurora.Level, msg string, fields ...aurora.Field) Entry {
+	r.t.Helper()
+
+	if e, ok := r.Find(level, msg, fields...); ok {
+		return e
+	}
+	r.t.Fatalf("auroratest: no %s entry %q with %s\nrecorded:\n%s",
+		level, msg, describeFields(fields), r.dump())
+	return Entry{}
+}
+
+func (r *Recorder) RequireNoEntry(level aurora.Level, msg string, fields ...aurora.Field) {
+	r.t.Helper()
+
+	r.mu.Lock()
+	i := r.find(level, msg, fields)
+	r.mu.Unlock()
+	if i >= 0 {
+		r.t.Fatalf("auroratest: unexpected %s entry %q with %s\nrecorded:\n%s",
+			level, msg, describeFields(fields), r.dump())
+	}
+}
+
+func (r *Recorder) find(level aurora.Level, msg string, fields []aurora.Field) int {
+	for i, e := range r.entries {
+		if e.Level == level && e.Message == msg && hasFields(e, fields) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Recorder) dump() string {
+	var b strings.Builder
+	for _, e := range r.Entries() {
+		b.WriteString("  ")
+		b.WriteString(e.String())
+		b.WriteString("\n")
+	}
+	if b.Len() == 0 {
+		return "  (none)\n"
+	}
+	return b.String()
+}
+
+func (r *Recorder) check() {
+	if !r.failOnError {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, e := range r.entries {
+		if e.Level >= aurora.ErrorLevel && !r.matched[i] {
+			r.t.Errorf("auroratest: unexpected %s entry: %s", e.Level, e)
+		}
+	}
+}
+
+func hasFields(e Entry, want []aurora.Field) bool {
+	for _, w := range want {
+		found := false
+		for _, f := range e.Fields {
+			if f.Key == w.Key && fieldEqual(f, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func fieldEqual(a, b aurora.Field) bool {
+	if reflect.DeepEqual(a.Any(), b.Any()) {
+		return true
+	}
+	return string(a.AppendText(nil)) == string(b.AppendText(nil))
+}
+
+func describeFields(fields []aurora.Field) string {
+	if len(fields) == 0 {
+		return "any fields"
+	}
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%s=%s", f.Key, f.AppendText(nil))
+	}
+	return strings.Join(parts, " ")
+}
+
+type tbSink struct {
+	t         testing.TB
+	level     aurora.Level
+	formatter aurora.Formatter
+
+	mu   sync.Mutex
+	done bool
+}
+
+func NewTBSink(t testing.TB, level aurora.Level, f aurora.Formatter) aurora.Sink {
+	if f == nil {
+		f = format.Pretty{NoColor: true}
+	}
+	s := &tbSink{t: t, level: level, formatter: f}
+	t.Cleanup(func() {
+		s.mu.Lock()
+		s.done = true
+		s.mu.Unlock()
+	})
+	return s
+}
+
+func (s *tbSink) Enabled(level aurora.Level) bool {
+	return level >= s.level
+}
+
+func (s *tbSink) Write(rec *aurora.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		return nil
+	}
+
+	line := s.formatter.Format(nil, rec)
+	s.t.Log(strings.Trim(string(line), "\n"))
+	return nil
+}
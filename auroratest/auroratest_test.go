@@ -0,0 +1,153 @@
+package auroratest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Summaw/aurora"
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type fakeTB struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+	failed   bool
+	logs     []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+	f.failed = true
+}
+
+func (f *fakeTB) Log(args ...any) {
+	f.logs = append(f.logs, fmt.Sprint(args...))
+}
+
+func (f *fakeTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func TestRequireEntryMatchesByContent(t *testing.T) {
+	log, rec := NewLogger(t)
+	log.With("component", "db").Info("connected").Int("port", 5432).Send()
+
+	e := rec.RequireEntry(aurora.InfoLevel, "connected",
+		format.Any("port", 5432),
+		format.Int64("port", 5432),
+		format.String("component", "db"))
+	if v, ok := e.Field("port"); !ok || fmt.Sprint(v) != "5432" {
+		t.Fatalf("Field(port) = %v, %v", v, ok)
+	}
+
+	if _, ok := rec.Find(aurora.InfoLevel, "connected", format.Any("port", 80)); ok {
+		t.Fatal("Find matched a different field value")
+	}
+	if _, ok := rec.Find(aurora.WarnLevel, "connected"); ok {
+		t.Fatal("Find matched a different level")
+	}
+	rec.RequireNoEntry(aurora.InfoLevel, "disconnected")
+}
+
+func TestRequireEntryFailure(t *testing.T) {
+	tb := &fakeTB{}
+	log, rec := NewLogger(tb)
+	log.Info("hello").Str("user", "bob").Send()
+
+	rec.RequireEntry(aurora.InfoLevel, "hello", format.String("user", "alice"))
+	if !tb.failed {
+		t.Fatal("RequireEntry did not fail on a mismatched field")
+	}
+	if msg := strings.Join(tb.errors, "\n"); !strings.Contains(msg, "user=alice") || !strings.Contains(msg, "user=bob") {
+		t.Fatalf("failure message lacks want and recorded entries:\n%s", msg)
+	}
+}
+
+func TestFailOnError(t *testing.T) {
+	tb := &fakeTB{}
+	log, rec := NewLogger(tb, FailOnError())
+	log.Error("expected").Send()
+	log.Error("unexpected").Send()
+	log.Warn("ignored").Send()
+
+	rec.RequireEntry(aurora.ErrorLevel, "expected")
+	tb.finish()
+
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "unexpected") {
+		t.Fatalf("cleanup errors = %q, want one for the unexpected entry", tb.errors)
+	}
+}
+
+func TestFailOnErrorAfterReset(t *testing.T) {
+	tb := &fakeTB{}
+	log, rec := NewLogger(tb, FailOnError())
+	log.Error("cleared").Send()
+	rec.Reset()
+	tb.finish()
+
+	if len(tb.errors) != 0 {
+		t.Fatalf("cleanup errors = %q, want none", tb.errors)
+	}
+}
+
+func TestFatalRecordsExit(t *testing.T) {
+	ran := false
+	aurora.RegisterExitHandler(func() { ran = true })
+
+	log, rec := NewLogger(t)
+	if _, ok := rec.Exited(); ok {
+		t.Fatal("Exited before any Fatal entry")
+	}
+
+	log.Fatal("giving up").Send()
+
+	code, ok := rec.Exited()
+	if !ok || code != 1 {
+		t.Fatalf("Exited() = %d, %v; want 1, true", code, ok)
+	}
+	if ran {
+		t.Fatal("Fatal on a test logger ran the process exit handlers")
+	}
+	rec.RequireEntry(aurora.FatalLevel, "giving up")
+}
+
+func TestWithLevel(t *testing.T) {
+	log, rec := NewLogger(t, WithLevel(aurora.WarnLevel))
+	log.Info("dropped").Send()
+	log.Warn("kept").Send()
+
+	if n := rec.Len(); n != 1 {
+		t.Fatalf("Len() = %d, want 1", n)
+	}
+	rec.RequireEntry(aurora.WarnLevel, "kept")
+}
+
+func TestTBSinkStopsAfterCleanup(t *testing.T) {
+	tb := &fakeTB{}
+	log := aurora.New(aurora.WithSinks(NewTBSink(tb, aurora.TraceLevel, nil)))
+
+	log.Info("during").Send()
+	tb.finish()
+	log.Info("after").Send()
+
+	if len(tb.logs) != 1 || !strings.Contains(tb.logs[0], "during") {
+		t.Fatalf("logs = %q, want only the entry written during the test", tb.logs)
+	}
+	if strings.HasPrefix(tb.logs[0], "\n") || strings.HasSuffix(tb.logs[0], "\n") {
+		t.Fatalf("log line not trimmed: %q", tb.logs[0])
+	}
+}
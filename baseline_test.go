@@ -0,0 +1,182 @@
+package aurora
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The baseline types are a copy of the logger as it was before entries were
+// pooled and fields were typed: every entry is heap-allocated, values are
+// boxed, and JSON is built with strings.Builder and fmt.Sprintf. The
+// Baseline benchmarks run it so the current hot path has something to be
+// compared against.
+
+type baselineField struct {
+	Key   string
+	Value any
+}
+
+type baselineLogger struct {
+	mu     sync.Mutex
+	level  Level
+	output io.Writer
+	fields []baselineField
+}
+
+type baselineEntry struct {
+	logger    *baselineLogger
+	Level     Level
+	Message   string
+	Timestamp time.Time
+	Fields    []baselineField
+	Caller    string
+	discard   bool
+}
+
+func (l *baselineLogger) newEntry(level Level, msg string) *baselineEntry {
+	if level < l.level {
+		return &baselineEntry{logger: l, discard: true}
+	}
+
+	return &baselineEntry{
+		logger:    l,
+		Level:     level,
+		Message:   msg,
+		Timestamp: time.Now(),
+		Fields:    append([]baselineField{}, l.fields...),
+	}
+}
+
+func (l *baselineLogger) Debug(msg string) *baselineEntry {
+	return l.newEntry(DebugLevel, msg)
+}
+
+func (l *baselineLogger) Info(msg string) *baselineEntry {
+	return l.newEntry(InfoLevel, msg)
+}
+
+func (e *baselineEntry) Str(key, value string) *baselineEntry {
+	if e.discard {
+		return e
+	}
+	e.Fields = append(e.Fields, baselineField{Key: key, Value: value})
+	return e
+}
+
+func (e *baselineEntry) Int(key string, value int) *baselineEntry {
+	if e.discard {
+		return e
+	}
+	e.Fields = append(e.Fields, baselineField{Key: key, Value: value})
+	return e
+}
+
+func (e *baselineEntry) Float64(key string, value float64) *baselineEntry {
+	if e.discard {
+		return e
+	}
+	e.Fields = append(e.Fields, baselineField{Key: key, Value: value})
+	return e
+}
+
+func (e *baselineEntry) Bool(key string, value bool) *baselineEntry {
+	if e.discard {
+		return e
+	}
+	e.Fields = append(e.Fields, baselineField{Key: key, Value: value})
+	return e
+}
+
+func (e *baselineEntry) Dur(key string, value time.Duration) *baselineEntry {
+	if e.discard {
+		return e
+	}
+	e.Fields = append(e.Fields, baselineField{Key: key, Value: baselineDuration(value)})
+	return e
+}
+
+func (e *baselineEntry) Send() {
+	if e.discard {
+		return
+	}
+	e.logger.write(e)
+}
+
+func (l *baselineLogger) write(entry *baselineEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	output := l.formatJSON(entry)
+	l.output.Write([]byte(output))
+}
+
+func (l *baselineLogger) formatJSON(entry *baselineEntry) string {
+	var sb strings.Builder
+
+	sb.WriteString(`{"timestamp":"`)
+	sb.WriteString(entry.Timestamp.Format(time.RFC3339Nano))
+	sb.WriteString(`","level":"`)
+	sb.WriteString(entry.Level.String())
+	sb.WriteString(`","message":"`)
+	sb.WriteString(baselineEscapeJSON(entry.Message))
+	sb.WriteString(`"`)
+
+	for _, field := range entry.Fields {
+		sb.WriteString(`,"`)
+		sb.WriteString(field.Key)
+		sb.WriteString(`":`)
+		sb.WriteString(baselineJSONValue(field.Value))
+	}
+
+	if entry.Caller != "" {
+		sb.WriteString(`,"caller":"`)
+		sb.WriteString(entry.Caller)
+		sb.WriteString(`"`)
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func baselineEscapeJSON(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	s = strings.ReplaceAll(s, "\r", `\r`)
+	s = strings.ReplaceAll(s, "\t", `\t`)
+	return s
+}
+
+func baselineJSONValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return `"` + baselineEscapeJSON(val) + `"`
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", val)
+	case float32, float64:
+		return fmt.Sprintf("%g", val)
+	case bool:
+		return fmt.Sprintf("%t", val)
+	default:
+		return `"` + baselineEscapeJSON(fmt.Sprintf("%v", val)) + `"`
+	}
+}
+
+func baselineDuration(d time.Duration) string {
+	if d < time.Microsecond {
+		return fmt.Sprintf("%dns", d.Nanoseconds())
+	}
+	if d < time.Millisecond {
+		return fmt.Sprintf("%.2fµs", float64(d.Nanoseconds())/1000)
+	}
+	if d < time.Second {
+		return fmt.Sprintf("%.2fms", float64(d.Nanoseconds())/1e6)
+	}
+	if d < time.Minute {
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	}
+	return d.String()
+}
@@ -0,0 +1,121 @@
+package aurora
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func benchLoggers() (pretty, jsonLog *Logger) {
+	pretty = New(WithOutput(io.Discard))
+	jsonLog = New(WithOutput(io.Discard), WithJSON(true))
+	return pretty, jsonLog
+}
+
+func logDisabled(log *Logger, i int) {
+	log.Debug("disabled").Str("key", "value").Int("n", i).Send()
+}
+
+func logNoFields(log *Logger) {
+	log.Info("request").Send()
+}
+
+func logTypedFields(log *Logger) {
+	log.Info("request").
+		Str("method", "GET").
+		Str("path", "/api/users").
+		Int("status", 200).
+		Bool("cached", true).
+		Float64("ratio", 0.5).
+		Dur("latency", 1500*time.Microsecond).
+		Send()
+}
+
+func BenchmarkDisabled(b *testing.B) {
+	_, log := benchLoggers()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logDisabled(log, i)
+	}
+}
+
+func BenchmarkJSONNoFields(b *testing.B) {
+	_, log := benchLoggers()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logNoFields(log)
+	}
+}
+
+func BenchmarkJSONTypedFields(b *testing.B) {
+	_, log := benchLoggers()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logTypedFields(log)
+	}
+}
+
+func BenchmarkPrettyTypedFields(b *testing.B) {
+	log, _ := benchLoggers()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logTypedFields(log)
+	}
+}
+
+func BenchmarkBaselineDisabled(b *testing.B) {
+	log := &baselineLogger{level: InfoLevel, output: io.Discard}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		log.Debug("disabled").Str("key", "value").Int("n", i).Send()
+	}
+}
+
+func BenchmarkBaselineJSONNoFields(b *testing.B) {
+	log := &baselineLogger{level: InfoLevel, output: io.Discard}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		log.Info("request").Send()
+	}
+}
+
+func BenchmarkBaselineJSONTypedFields(b *testing.B) {
+	log := &baselineLogger{level: InfoLevel, output: io.Discard}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		log.Info("request").
+			Str("method", "GET").
+			Str("path", "/api/users").
+			Int("status", 200).
+			Bool("cached", true).
+			Float64("ratio", 0.5).
+			Dur("latency", 1500*time.Microsecond).
+			Send()
+	}
+}
+
+func TestZeroAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random under the race detector")
+	}
+	if testing.CoverMode() != "" {
+		t.Skip("coverage instrumentation allocates")
+	}
+
+	pretty, jsonLog := benchLoggers()
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Disabled", func() { logDisabled(jsonLog, 1) }},
+		{"JSONNoFields", func() { logNoFields(jsonLog) }},
+		{"JSONTypedFields", func() { logTypedFields(jsonLog) }},
+		{"PrettyTypedFields", func() { logTypedFields(pretty) }},
+	}
+	for _, tt := range tests {
+		tt.fn()
+		if allocs := testing.AllocsPerRun(100, tt.fn); allocs != 0 {
+			t.Errorf("%s: %v allocs per entry, want 0", tt.name, allocs)
+		}
+	}
+}
@@ -1,12 +1,106 @@
 package aurora
 
-import "io"
+import (
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/Summaw/aurora/pkg/color"
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type Formatter = format.Formatter
+
+type Record = format.Record
 
 type Config struct {
-	Output      io.Writer
-	Level       Level
-	TimeFormat  string
-	ShowCaller  bool
-	CallerDepth int
-	JSONOutput  bool
+	Output         io.Writer
+	Level          Level
+	LevelRules     []LevelRule
+	LevelConfigs   map[Level]LevelConfig
+	TimeFormat     string
+	ShowCaller     bool
+	CallerDepth    int
+	Formatter      Formatter
+	NoColor        bool
+	Sinks          []Sink
+	Sampler        Sampler
+	Hooks          []Hook
+	Redactor       *Redactor
+	ExitFunc       func(code int)
+	NoExitHandlers bool
+
+	// Deprecated: use Formatter or WithJSON. JSONOutput is only honoured
+	// when Formatter is nil.
+	JSONOutput bool
+
+	ContextExtractors []ContextExtractor
+
+	dedup       *deduper
+	levelTimer  *time.Timer
+	levelBase   Level
+	levelExpiry time.Time
+
+	output Formatter
+
+	level atomic.Int64
+	rules atomic.Pointer[[]LevelRule]
+	sinks atomic.Pointer[[]Sink]
+	hooks atomic.Pointer[[]Hook]
+}
+
+func (c *Config) refreshOutput() {
+	f := c.Formatter
+	if f == nil && c.JSONOutput {
+		f = format.JSON{}
+	}
+	if f == nil {
+		f = format.Pretty{TimeFormat: c.TimeFormat, NoColor: c.NoColor}
+	}
+	if cf, ok := f.(format.ColorFormatter); ok {
+		f = cf.WithProfile(color.ProfileFor(c.Output))
+	}
+	c.output = f
+}
+
+func (c *Config) setLevel(level Level) {
+	c.Level = level
+	c.level.Store(int64(level))
+}
+
+func (c *Config) setLevelRules(rules []LevelRule) {
+	c.LevelRules = rules
+	c.rules.Store(&rules)
+}
+
+func (c *Config) loadLevel(name string) Level {
+	level := Level(c.level.Load())
+	if rules := c.rules.Load(); rules != nil && len(*rules) > 0 {
+		return resolveLevel(*rules, name, level)
+	}
+	return level
+}
+
+func (c *Config) setSinks(sinks []Sink) {
+	c.Sinks = sinks
+	c.sinks.Store(&sinks)
+}
+
+func (c *Config) loadSinks() []Sink {
+	if sinks := c.sinks.Load(); sinks != nil {
+		return *sinks
+	}
+	return nil
+}
+
+func (c *Config) setHooks(hooks []Hook) {
+	c.Hooks = hooks
+	c.hooks.Store(&hooks)
+}
+
+func (c *Config) loadHooks() []Hook {
+	if hooks := c.hooks.Load(); hooks != nil {
+		return *hooks
+	}
+	return nil
 }
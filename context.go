@@ -0,0 +1,85 @@
+package aurora
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type ContextKey string
+
+const (
+	RequestIDKey ContextKey = "request_id"
+	TraceIDKey   ContextKey = "trace_id"
+	SpanIDKey    ContextKey = "span_id"
+)
+
+type loggerKey struct{}
+
+type ContextExtractor func(ctx context.Context) []Field
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   = []ContextExtractor{extractIDs}
+)
+
+func RegisterContextExtractor(fn ContextExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+	extractors = append(extractors, fn)
+}
+
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerKey{}).(*Logger); ok && l != nil {
+			return l
+		}
+	}
+	return Default()
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, id)
+}
+
+func WithTraceID(ctx context.Context, traceID, spanID string) context.Context {
+	ctx = context.WithValue(ctx, TraceIDKey, traceID)
+	if spanID != "" {
+		ctx = context.WithValue(ctx, SpanIDKey, spanID)
+	}
+	return ctx
+}
+
+func extractIDs(ctx context.Context) []Field {
+	var fields []Field
+	for _, key := range []ContextKey{RequestIDKey, TraceIDKey, SpanIDKey} {
+		if v := ctx.Value(key); v != nil {
+			fields = append(fields, format.Any(string(key), v))
+		}
+	}
+	return fields
+}
+
+func (l *Logger) contextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+
+	var fields []Field
+
+	extractorsMu.RLock()
+	for _, fn := range extractors {
+		fields = append(fields, fn(ctx)...)
+	}
+	extractorsMu.RUnlock()
+
+	for _, fn := range l.config.ContextExtractors {
+		fields = append(fields, fn(ctx)...)
+	}
+	return fields
+}
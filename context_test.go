@@ -0,0 +1,89 @@
+package aurora
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type testCtxKey string
+
+func TestNewContextFromContext(t *testing.T) {
+	log := New(WithOutput(&bytes.Buffer{}))
+	ctx := NewContext(context.Background(), log)
+	if got := FromContext(ctx); got != log {
+		t.Fatalf("FromContext returned %p, want %p", got, log)
+	}
+
+	if got := FromContext(context.Background()); got != Default() {
+		t.Fatal("FromContext without a logger did not return Default()")
+	}
+	var nilCtx context.Context
+	if got := FromContext(nilCtx); got != Default() {
+		t.Fatal("FromContext(nil) did not return Default()")
+	}
+	if got := FromContext(NewContext(context.Background(), nil)); got != Default() {
+		t.Fatal("FromContext with a nil logger did not return Default()")
+	}
+}
+
+func TestCtxTypedKeys(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(WithOutput(&buf), WithFormatter(format.JSON{TimeKey: "-"}))
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	ctx = WithTraceID(ctx, "trace-1", "span-1")
+	ctx = context.WithValue(ctx, testCtxKey("request_id"), "other")
+	log.Ctx(ctx).Info("hello").Send()
+
+	want := `{"level":"INFO","message":"hello","request_id":"req-1","trace_id":"trace-1","span_id":"span-1"}` + "\n"
+	if buf.String() != want {
+		t.Fatalf("got  %s\nwant %s", buf.String(), want)
+	}
+
+	buf.Reset()
+	log.Ctx(WithTraceID(context.Background(), "trace-2", "")).Info("no span").Send()
+	if want := `{"level":"INFO","message":"no span","trace_id":"trace-2"}` + "\n"; buf.String() != want {
+		t.Fatalf("got  %s\nwant %s", buf.String(), want)
+	}
+}
+
+func TestContextExtractors(t *testing.T) {
+	RegisterContextExtractor(func(ctx context.Context) []Field {
+		if v, ok := ctx.Value(testCtxKey("tenant")).(string); ok {
+			return []Field{format.String("tenant", v)}
+		}
+		return nil
+	})
+
+	var buf bytes.Buffer
+	log := New(WithOutput(&buf), WithFormatter(format.JSON{TimeKey: "-"}),
+		WithContextExtractor(func(ctx context.Context) []Field {
+			if v, ok := ctx.Value(testCtxKey("user")).(string); ok {
+				return []Field{format.String("user", v)}
+			}
+			return nil
+		}))
+
+	ctx := context.WithValue(context.Background(), testCtxKey("tenant"), "acme")
+	ctx = context.WithValue(ctx, testCtxKey("user"), "bob")
+	ctx = WithRequestID(ctx, "req-1")
+
+	log.Ctx(ctx).Info("ctx").Send()
+	slog.New(NewSlogHandler(log)).InfoContext(ctx, "slog")
+
+	want := `{"level":"INFO","message":"ctx","request_id":"req-1","tenant":"acme","user":"bob"}` + "\n" +
+		`{"level":"INFO","message":"slog","request_id":"req-1","tenant":"acme","user":"bob"}` + "\n"
+	if buf.String() != want {
+		t.Fatalf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	New(WithOutput(&buf), WithFormatter(format.JSON{TimeKey: "-"})).Ctx(ctx).Info("global only").Send()
+	if want := `{"level":"INFO","message":"global only","request_id":"req-1","tenant":"acme"}` + "\n"; buf.String() != want {
+		t.Fatalf("got  %s\nwant %s", buf.String(), want)
+	}
+}
@@ -2,13 +2,13 @@ package aurora
 
 import (
 	"fmt"
+	"sync"
 	"time"
+
+	"github.com/Summaw/aurora/pkg/format"
 )
 
-type Field struct {
-	Key   string
-	Value any
-}
+type Field = format.Field
 
 type F map[string]any
 
@@ -22,13 +22,51 @@ type Entry struct {
 	discard   bool
 	fatal     bool
 	doPanic   bool
+	rec       Record
+}
+
+var discardEntry = &Entry{discard: true}
+
+var entryPool = sync.Pool{
+	New: func() any {
+		return &Entry{Fields: make([]Field, 0, 8)}
+	},
+}
+
+func getEntry() *Entry {
+	return entryPool.Get().(*Entry)
+}
+
+func putEntry(e *Entry) {
+	if cap(e.Fields) > 64 {
+		return
+	}
+	fields := e.Fields[:0]
+	*e = Entry{}
+	e.Fields = fields
+	entryPool.Put(e)
+}
+
+func (e *Entry) Clone() *Entry {
+	c := &Entry{
+		Level:     e.Level,
+		Message:   e.Message,
+		Timestamp: e.Timestamp,
+		Fields:    make([]Field, len(e.Fields)),
+		Caller:    e.Caller,
+		discard:   true,
+	}
+	for i, f := range e.Fields {
+		c.Fields[i] = f.Snapshot()
+	}
+	return c
 }
 
 func (e *Entry) Str(key, value string) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value})
+	e.Fields = append(e.Fields, format.String(key, value))
 	return e
 }
 
@@ -36,7 +74,7 @@ func (e *Entry) Int(key string, value int) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value})
+	e.Fields = append(e.Fields, format.Int64(key, int64(value)))
 	return e
 }
 
@@ -44,7 +82,7 @@ func (e *Entry) Int64(key string, value int64) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value})
+	e.Fields = append(e.Fields, format.Int64(key, value))
 	return e
 }
 
@@ -52,7 +90,7 @@ func (e *Entry) Uint(key string, value uint) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value})
+	e.Fields = append(e.Fields, format.Uint64(key, uint64(value)))
 	return e
 }
 
@@ -60,7 +98,7 @@ func (e *Entry) Uint64(key string, value uint64) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value})
+	e.Fields = append(e.Fields, format.Uint64(key, value))
 	return e
 }
 
@@ -68,7 +106,7 @@ func (e *Entry) Float32(key string, value float32) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value})
+	e.Fields = append(e.Fields, format.Float32(key, value))
 	return e
 }
 
@@ -76,7 +114,7 @@ func (e *Entry) Float64(key string, value float64) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value})
+	e.Fields = append(e.Fields, format.Float64(key, value))
 	return e
 }
 
@@ -84,7 +122,7 @@ func (e *Entry) Bool(key string, value bool) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value})
+	e.Fields = append(e.Fields, format.Bool(key, value))
 	return e
 }
 
@@ -92,7 +130,7 @@ func (e *Entry) Dur(key string, value time.Duration) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: formatDuration(value)})
+	e.Fields = append(e.Fields, format.Duration(key, value))
 	return e
 }
 
@@ -100,7 +138,7 @@ func (e *Entry) Time(key string, value time.Time) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value.Format(time.RFC3339)})
+	e.Fields = append(e.Fields, format.Time(key, value))
 	return e
 }
 
@@ -108,7 +146,25 @@ func (e *Entry) Any(key string, value any) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value})
+	e.Fields = append(e.Fields, format.Any(key, value))
+	return e
+}
+
+func (e *Entry) Dict(key string, fn func(d *Entry)) *Entry {
+	if e.discard {
+		return e
+	}
+	dict := &Entry{logger: e.logger}
+	fn(dict)
+	e.Fields = append(e.Fields, format.Group(key, dict.Fields...))
+	return e
+}
+
+func (e *Entry) Array(key string, values ...any) *Entry {
+	if e.discard {
+		return e
+	}
+	e.Fields = append(e.Fields, format.Any(key, values))
 	return e
 }
 
@@ -116,7 +172,23 @@ func (e *Entry) Err(err error) *Entry {
 	if e.discard || err == nil {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: "error", Value: err.Error()})
+	e.Fields = append(e.Fields, format.Error("error", err))
+	return e
+}
+
+func (e *Entry) AnErr(key string, err error) *Entry {
+	if e.discard || err == nil {
+		return e
+	}
+	e.Fields = append(e.Fields, format.Error(key, err))
+	return e
+}
+
+func (e *Entry) Stack() *Entry {
+	if e.discard {
+		return e
+	}
+	e.Fields = append(e.Fields, format.Stack("stack", format.CaptureStack(1)))
 	return e
 }
 
@@ -124,7 +196,7 @@ func (e *Entry) Field(key string, value any) *Entry {
 	if e.discard {
 		return e
 	}
-	e.Fields = append(e.Fields, Field{Key: key, Value: value})
+	e.Fields = append(e.Fields, format.Any(key, value))
 	return e
 }
 
@@ -133,7 +205,7 @@ func (e *Entry) WithFields(fields F) *Entry {
 		return e
 	}
 	for k, v := range fields {
-		e.Fields = append(e.Fields, Field{Key: k, Value: v})
+		e.Fields = append(e.Fields, format.Any(k, v))
 	}
 	return e
 }
@@ -161,18 +233,14 @@ func (e *Entry) Send() {
 	e.logger.write(e)
 }
 
-func formatDuration(d time.Duration) string {
-	if d < time.Microsecond {
-		return fmt.Sprintf("%dns", d.Nanoseconds())
-	}
-	if d < time.Millisecond {
-		return fmt.Sprintf("%.2fµs", float64(d.Nanoseconds())/1000)
-	}
-	if d < time.Second {
-		return fmt.Sprintf("%.2fms", float64(d.Nanoseconds())/1e6)
-	}
-	if d < time.Minute {
-		return fmt.Sprintf("%.2fs", d.Seconds())
+func (e *Entry) record() *Record {
+	e.rec = Record{
+		Time:    e.Timestamp,
+		Level:   e.Level.format(e.logger.config.LevelConfigs),
+		Message: e.Message,
+		Fields:  e.Fields,
+		Caller:  e.Caller,
+		Name:    e.logger.name,
 	}
-	return d.String()
+	return &e.rec
 }
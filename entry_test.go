@@ -0,0 +1,94 @@
+package aurora
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type point struct{ X, Y int }
+
+func (p point) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + strings.Repeat("*", p.X) + `"`), nil
+}
+
+type account struct {
+	Name    string   `json:"name"`
+	Roles   []string `json:"roles"`
+	Secret  string   `json:"-"`
+	Balance float64
+	note    string
+}
+
+func logNested(f Formatter) string {
+	var buf bytes.Buffer
+	log := New(WithOutput(&buf), WithFormatter(f))
+	log.Info("nested").
+		Dict("req", func(d *Entry) {
+			d.Str("method", "GET").Dict("client", func(d *Entry) {
+				d.Str("ip", "10.0.0.1")
+			})
+		}).
+		Array("tags", "a", 2, true).
+		Any("limits", map[string]any{"b": []int{1, 2}, "a": 1}).
+		Any("account", account{Name: "bob", Roles: []string{"admin"}, Secret: "s", Balance: 1.5, note: "n"}).
+		Any("point", point{X: 3}).
+		Send()
+	return buf.String()
+}
+
+func TestNestedFieldsJSON(t *testing.T) {
+	got := logNested(format.JSON{TimeKey: "-"})
+	want := `{"level":"INFO","message":"nested",` +
+		`"req":{"method":"GET","client":{"ip":"10.0.0.1"}},` +
+		`"tags":["a",2,true],` +
+		`"limits":{"a":1,"b":[1,2]},` +
+		`"account":{"name":"bob","roles":["admin"],"Balance":1.5},` +
+		`"point":"***"}` + "\n"
+	if got != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestNestedFieldsPretty(t *testing.T) {
+	got := logNested(format.Pretty{NoColor: true, TimeFormat: "-"})
+	want := strings.Join([]string{
+		"",
+		"  -  ● INFO  nested",
+		"            ├─ req:",
+		"            │  ├─ method: GET",
+		"            │  └─ client:",
+		"            │     └─ ip: 10.0.0.1",
+		"            ├─ tags:",
+		"            │  ├─ [0]: a",
+		"            │  ├─ [1]: 2",
+		"            │  └─ [2]: true",
+		"            ├─ limits:",
+		"            │  ├─ a: 1",
+		"            │  └─ b:",
+		"            │     ├─ [0]: 1",
+		"            │     └─ [1]: 2",
+		"            ├─ account:",
+		"            │  ├─ name: bob",
+		"            │  ├─ roles:",
+		"            │  │  └─ [0]: admin",
+		"            │  └─ Balance: 1.5",
+		"            └─ point: {3 0}",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDictOnDisabledEntry(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(WithOutput(&buf), WithLevel(ErrorLevel))
+	called := false
+	log.Info("skipped").Dict("d", func(d *Entry) { called = true }).Array("a", 1).Send()
+	if called || buf.Len() != 0 {
+		t.Fatalf("disabled entry ran Dict (%v) or wrote %q", called, buf.String())
+	}
+}
@@ -0,0 +1,64 @@
+package aurora
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+type PanicError struct {
+	Entry *Entry
+}
+
+func (p *PanicError) Error() string {
+	return p.Entry.Message
+}
+
+var (
+	exitHandlersMu sync.Mutex
+	exitHandlers   []func()
+)
+
+func RegisterExitHandler(fn func()) {
+	exitHandlersMu.Lock()
+	defer exitHandlersMu.Unlock()
+	exitHandlers = append(exitHandlers, fn)
+}
+
+func runExitHandlers() {
+	exitHandlersMu.Lock()
+	handlers := append([]func(){}, exitHandlers...)
+	exitHandlersMu.Unlock()
+
+	for _, fn := range handlers {
+		runExitHandler(fn)
+	}
+}
+
+func runExitHandler(fn func()) {
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Fprintf(os.Stderr, "aurora: exit handler panicked: %v\n", err)
+		}
+	}()
+	fn()
+}
+
+func (l *Logger) exit(code int) {
+	if !l.config.NoExitHandlers {
+		runExitHandlers()
+	}
+	if err := l.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "aurora: closing sinks: %v\n", err)
+	}
+
+	if l.config.ExitFunc != nil {
+		l.config.ExitFunc(code)
+		return
+	}
+	os.Exit(code)
+}
+
+func Exit(code int) {
+	Default().exit(code)
+}
@@ -0,0 +1,150 @@
+package aurora
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type orderSink struct {
+	mu    sync.Mutex
+	order *[]string
+	delay time.Duration
+}
+
+func (s *orderSink) Enabled(Level) bool { return true }
+
+func (s *orderSink) Write(r *Record) error {
+	time.Sleep(s.delay)
+	s.mu.Lock()
+	*s.order = append(*s.order, "write "+r.Message)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *orderSink) Close() error {
+	s.mu.Lock()
+	*s.order = append(*s.order, "close")
+	s.mu.Unlock()
+	return nil
+}
+
+func TestFatalExitOrder(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	active := true
+	record := func(s string) func() {
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+			if active {
+				order = append(order, s)
+			}
+		}
+	}
+	// Exit handlers are global, so they stay registered for later tests and
+	// only record while this one runs.
+	defer func() {
+		mu.Lock()
+		active = false
+		mu.Unlock()
+	}()
+
+	RegisterExitHandler(record("first"))
+	RegisterExitHandler(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if active {
+			panic("broken handler")
+		}
+	})
+	RegisterExitHandler(record("third"))
+
+	sink := &orderSink{order: &order}
+	code := -1
+	log := New(WithSinks(sink), WithExitFunc(func(c int) {
+		code = c
+		order = append(order, "exit")
+	}))
+	log.Fatal("down").Send()
+
+	want := "write down, first, third, close, exit"
+	if got := joinOrder(order); got != want || code != 1 {
+		t.Fatalf("order = %s, code %d; want %s, code 1", got, code, want)
+	}
+
+	order, code = nil, -1
+	log = New(WithSinks(sink), WithNoExitHandlers(true), WithExitFunc(func(c int) {
+		code = c
+		order = append(order, "exit")
+	}))
+	log.Fatal("again").Send()
+	if got := joinOrder(order); got != "write again, close, exit" || code != 1 {
+		t.Fatalf("with NoExitHandlers: order = %s, code %d", got, code)
+	}
+}
+
+func joinOrder(order []string) string {
+	return strings.Join(order, ", ")
+}
+
+func TestFatalDrainsAsyncSink(t *testing.T) {
+	var order []string
+	inner := &orderSink{order: &order, delay: 100 * time.Microsecond}
+
+	var atExit []string
+	log := New(WithSinks(NewAsyncSink(inner, 64, OverflowBlock)), WithExitFunc(func(int) {
+		inner.mu.Lock()
+		atExit = append(atExit, order...)
+		inner.mu.Unlock()
+	}))
+	for i := 0; i < 50; i++ {
+		log.Info("m" + strconv.Itoa(i)).Send()
+	}
+	log.Fatal("down").Send()
+
+	if len(atExit) != 52 || atExit[50] != "write down" || atExit[51] != "close" {
+		t.Fatalf("sink state at exit: %d events, last %v", len(atExit), atExit[max(0, len(atExit)-2):])
+	}
+}
+
+func TestPanicError(t *testing.T) {
+	var order []string
+	log := New(WithSinks(&orderSink{order: &order}))
+
+	var got any
+	func() {
+		defer func() { got = recover() }()
+		log.Panic("boom").Str("k", "v").Err(errors.New("cause")).Send()
+	}()
+
+	pe, ok := got.(*PanicError)
+	if !ok {
+		t.Fatalf("recovered %T, want *PanicError", got)
+	}
+	if pe.Error() != "boom" || pe.Entry.Message != "boom" || pe.Entry.Level != PanicLevel {
+		t.Fatalf("payload = %q at %v", pe.Entry.Message, pe.Entry.Level)
+	}
+	if len(pe.Entry.Fields) != 2 || pe.Entry.Fields[0].Any() != "v" || pe.Entry.Fields[1].Key != "error" {
+		t.Fatalf("payload fields = %v", pe.Entry.Fields)
+	}
+	if len(order) != 1 || order[0] != "write boom" {
+		t.Fatalf("entry was not written before the panic: %v", order)
+	}
+
+	var fields []Field
+	func() {
+		defer func() {
+			pe := recover().(*PanicError)
+			fields = pe.Entry.Fields
+		}()
+		log.Panic("second").Int("n", 1).Send()
+	}()
+	log.Info("reuse").Str("other", "x").Send()
+	if len(fields) != 1 || fields[0].Key != "n" {
+		t.Fatalf("panic payload was recycled: %v", fields)
+	}
+}
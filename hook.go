@@ -0,0 +1,72 @@
+package aurora
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrDropEntry = errors.New("aurora: drop entry")
+
+type Hook interface {
+	Levels() []Level
+	Fire(e *Entry) error
+}
+
+type PostHook interface {
+	Hook
+	AfterWrite(e *Entry)
+}
+
+type funcHook struct {
+	levels []Level
+	fn     func(e *Entry) error
+}
+
+func NewHook(fn func(e *Entry) error, levels ...Level) Hook {
+	return &funcHook{levels: levels, fn: fn}
+}
+
+func (h *funcHook) Levels() []Level {
+	return h.levels
+}
+
+func (h *funcHook) Fire(e *Entry) error {
+	return h.fn(e)
+}
+
+func hookApplies(h Hook, level Level) bool {
+	levels := h.Levels()
+	if len(levels) == 0 {
+		return true
+	}
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Logger) fireHooks(entry *Entry) bool {
+	for _, h := range l.config.loadHooks() {
+		if !hookApplies(h, entry.Level) {
+			continue
+		}
+		if err := h.Fire(entry); err != nil {
+			if errors.Is(err, ErrDropEntry) {
+				return false
+			}
+			fmt.Fprintf(os.Stderr, "aurora: hook failed: %v\n", err)
+		}
+	}
+	return true
+}
+
+func (l *Logger) afterHooks(entry *Entry) {
+	for _, h := range l.config.loadHooks() {
+		if ph, ok := h.(PostHook); ok && hookApplies(h, entry.Level) {
+			ph.AfterWrite(entry)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package aurora
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+func TestHooksSeeRedactedEntries(t *testing.T) {
+	var buf bytes.Buffer
+	var seen []string
+	log := New(
+		WithOutput(&buf),
+		WithJSON(true),
+		WithRedactor(DefaultRedactor()),
+		WithHooks(NewHook(func(e *Entry) error {
+			for _, f := range e.Fields {
+				seen = append(seen, f.Key+"="+string(f.AppendText(nil)))
+			}
+			e.Fields = append(e.Fields, format.String("api_key", "added-by-hook"))
+			return nil
+		})),
+	)
+
+	log.Info("login").Str("user", "bob").Str("password", "hunter2").Send()
+
+	if got, want := strings.Join(seen, " "), "user=bob password=[REDACTED]"; got != want {
+		t.Fatalf("hook saw %q, want %q", got, want)
+	}
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "added-by-hook"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("output leaks %q: %s", secret, out)
+		}
+	}
+}
+
+func TestAddHookConcurrent(t *testing.T) {
+	log := New(WithOutput(io.Discard), WithJSON(true))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			log.Info("hello").Send()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			log.AddHook(NewHook(func(*Entry) error { return nil }))
+		}
+	}()
+	wg.Wait()
+
+	if got := len(log.config.loadHooks()); got != 100 {
+		t.Fatalf("hooks = %d, want 100", got)
+	}
+}
+
+func TestHookCloneOutlivesEntry(t *testing.T) {
+	var clones, kept []*Entry
+	log := New(
+		WithOutput(io.Discard),
+		WithJSON(true),
+		WithHooks(NewHook(func(e *Entry) error {
+			kept = append(kept, e)
+			clones = append(clones, e.Clone())
+			return nil
+		})),
+	)
+
+	m := map[string]int{"n": 1}
+	log.Error("first").Str("k", "1").Any("m", m).Send()
+	if kept[0].Message != "" || len(kept[0].Fields) != 0 {
+		t.Fatalf("entry passed to the hook was not recycled: %+v", kept[0])
+	}
+	m["n"] = 2
+	for i := 0; i < 100; i++ {
+		log.Error("second").Str("k", "2").Send()
+	}
+
+	first := clones[0]
+	if first.Message != "first" || first.Level != ErrorLevel || len(first.Fields) != 2 {
+		t.Fatalf("clone changed after the entry was recycled: %+v", first)
+	}
+	if v := first.Fields[0].Any(); v != "1" {
+		t.Fatalf("clone k = %v, want 1", v)
+	}
+	if v := first.Fields[1].Any().(map[string]int)["n"]; v != 1 {
+		t.Fatalf("clone m.n = %d, want 1", v)
+	}
+
+	first.Str("extra", "x").Send()
+	if len(first.Fields) != 2 {
+		t.Fatalf("field methods changed a clone: %v", first.Fields)
+	}
+}
@@ -1,19 +1,27 @@
 package aurora
 
-import "github.com/Summaw/aurora/pkg/color"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Summaw/aurora/pkg/color"
+	"github.com/Summaw/aurora/pkg/format"
+)
 
 type Level int
 
 const (
-	TraceLevel Level = iota
-	DebugLevel
-	InfoLevel
-	SuccessLevel
-	WarnLevel
-	ErrorLevel
-	FatalLevel
-	PanicLevel
-	Disabled
+	TraceLevel   Level = 0
+	DebugLevel   Level = 10
+	InfoLevel    Level = 20
+	SuccessLevel Level = 25
+	WarnLevel    Level = 30
+	ErrorLevel   Level = 40
+	FatalLevel   Level = 50
+	PanicLevel   Level = 60
+	Disabled     Level = 100
 )
 
 type LevelConfig struct {
@@ -23,7 +31,9 @@ type LevelConfig struct {
 	Bold  bool
 }
 
-var DefaultLevelConfigs = map[Level]LevelConfig{
+var levelsMu sync.RWMutex
+
+var levelConfigs = map[Level]LevelConfig{
 	TraceLevel: {
 		Name:  "TRACE",
 		Icon:  "◦",
@@ -74,46 +84,127 @@ var DefaultLevelConfigs = map[Level]LevelConfig{
 	},
 }
 
+// Deprecated: use LookupLevel, Levels and RegisterLevel. DefaultLevelConfigs
+// is a copy of the built-in levels; changing it has no effect.
+var DefaultLevelConfigs = copyLevelConfigs()
+
+func copyLevelConfigs() map[Level]LevelConfig {
+	configs := make(map[Level]LevelConfig, len(levelConfigs))
+	for l, cfg := range levelConfigs {
+		configs[l] = cfg
+	}
+	return configs
+}
+
+func RegisterLevel(level Level, cfg LevelConfig) error {
+	if cfg.Name == "" {
+		return fmt.Errorf("aurora: level %d needs a name", int(level))
+	}
+	if level >= Disabled {
+		return fmt.Errorf("aurora: level %s must be below Disabled", cfg.Name)
+	}
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	for l, existing := range levelConfigs {
+		if l != level && strings.EqualFold(existing.Name, cfg.Name) {
+			return fmt.Errorf("aurora: level name %q already used by level %d", cfg.Name, int(l))
+		}
+	}
+	levelConfigs[level] = cfg
+	return nil
+}
+
+func Levels() []Level {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+
+	levels := make([]Level, 0, len(levelConfigs))
+	for l := range levelConfigs {
+		levels = append(levels, l)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i] < levels[j]
+	})
+	return levels
+}
+
+func LookupLevel(l Level) (LevelConfig, bool) {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	cfg, ok := levelConfigs[l]
+	return cfg, ok
+}
+
 func (l Level) String() string {
-	if cfg, ok := DefaultLevelConfigs[l]; ok {
+	if cfg, ok := LookupLevel(l); ok {
 		return cfg.Name
 	}
+	if l == Disabled {
+		return "DISABLED"
+	}
 	return "UNKNOWN"
 }
 
 func (l Level) Icon() string {
-	if cfg, ok := DefaultLevelConfigs[l]; ok {
+	if cfg, ok := LookupLevel(l); ok {
 		return cfg.Icon
 	}
 	return "?"
 }
 
 func (l Level) Color() color.RGB {
-	if cfg, ok := DefaultLevelConfigs[l]; ok {
+	if cfg, ok := LookupLevel(l); ok {
 		return cfg.Color
 	}
 	return color.RGB{R: 255, G: 255, B: 255}
 }
 
-func ParseLevel(s string) Level {
-	switch s {
-	case "trace", "TRACE":
-		return TraceLevel
-	case "debug", "DEBUG":
-		return DebugLevel
-	case "info", "INFO":
-		return InfoLevel
-	case "success", "SUCCESS":
-		return SuccessLevel
-	case "warn", "WARN", "warning", "WARNING":
-		return WarnLevel
-	case "error", "ERROR":
-		return ErrorLevel
-	case "fatal", "FATAL":
-		return FatalLevel
-	case "panic", "PANIC":
-		return PanicLevel
-	default:
-		return InfoLevel
+func (l Level) format(overrides map[Level]LevelConfig) format.Level {
+	cfg, ok := overrides[l]
+	if !ok {
+		cfg, ok = LookupLevel(l)
+	}
+	if !ok {
+		cfg = LevelConfig{Name: l.String(), Icon: l.Icon(), Color: l.Color()}
+	}
+	if cfg.Name == "" {
+		cfg.Name = l.String()
+	}
+	return format.Level{
+		Value: int(l),
+		Name:  cfg.Name,
+		Icon:  cfg.Icon,
+		Color: cfg.Color,
+		Bold:  cfg.Bold,
+	}
+}
+
+func ParseLevel(s string) (Level, error) {
+	name := strings.TrimSpace(s)
+	switch strings.ToLower(name) {
+	case "warning":
+		return WarnLevel, nil
+	case "disabled", "off", "none":
+		return Disabled, nil
+	}
+
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+
+	for l, cfg := range levelConfigs {
+		if strings.EqualFold(cfg.Name, name) {
+			return l, nil
+		}
+	}
+	return InfoLevel, fmt.Errorf("aurora: unknown level %q", s)
+}
+
+func MustParseLevel(s string) Level {
+	level, err := ParseLevel(s)
+	if err != nil {
+		panic(err)
 	}
+	return level
 }
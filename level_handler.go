@@ -0,0 +1,117 @@
+package aurora
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type levelPayload struct {
+	Level   string `json:"level"`
+	TTL     string `json:"ttl,omitempty"`
+	Expires string `json:"expires,omitempty"`
+}
+
+func LevelHandler(l *Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		asJSON := strings.Contains(r.Header.Get("Accept"), "application/json") ||
+			strings.Contains(r.Header.Get("Content-Type"), "application/json")
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut, http.MethodPost:
+			payload, err := readLevelPayload(r)
+			if err != nil {
+				writeLevelError(w, asJSON, http.StatusBadRequest, err)
+				return
+			}
+
+			level, err := ParseLevel(payload.Level)
+			if err != nil {
+				writeLevelError(w, asJSON, http.StatusBadRequest, err)
+				return
+			}
+
+			var ttl time.Duration
+			if payload.TTL != "" {
+				ttl, err = time.ParseDuration(payload.TTL)
+				if err != nil || ttl < 0 {
+					writeLevelError(w, asJSON, http.StatusBadRequest, fmt.Errorf("invalid ttl %q", payload.TTL))
+					return
+				}
+			}
+
+			if ttl > 0 {
+				l.SetLevelFor(level, ttl)
+			} else {
+				l.SetLevel(level)
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			writeLevelError(w, asJSON, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+
+		writeLevel(w, l, asJSON)
+	})
+}
+
+func readLevelPayload(r *http.Request) (levelPayload, error) {
+	var payload levelPayload
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1024))
+	if err != nil {
+		return payload, err
+	}
+
+	if strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return payload, err
+		}
+	} else {
+		payload.Level = strings.TrimSpace(string(body))
+	}
+
+	if payload.Level == "" {
+		payload.Level = r.URL.Query().Get("level")
+	}
+	if payload.TTL == "" {
+		payload.TTL = r.URL.Query().Get("ttl")
+	}
+	return payload, nil
+}
+
+func writeLevel(w http.ResponseWriter, l *Logger, asJSON bool) {
+	level := strings.ToLower(l.GetLevel().String())
+	expires := l.LevelExpiry()
+
+	if asJSON {
+		payload := levelPayload{Level: level}
+		if !expires.IsZero() {
+			payload.Expires = expires.Format(time.RFC3339)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(payload)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if expires.IsZero() {
+		fmt.Fprintln(w, level)
+		return
+	}
+	fmt.Fprintf(w, "%s (until %s)\n", level, expires.Format(time.RFC3339))
+}
+
+func writeLevelError(w http.ResponseWriter, asJSON bool, status int, err error) {
+	if asJSON {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	http.Error(w, err.Error(), status)
+}
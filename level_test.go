@@ -0,0 +1,55 @@
+package aurora
+
+import (
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestRegisterLevelConcurrent(t *testing.T) {
+	log := New(WithOutput(io.Discard), WithLevel(TraceLevel))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			log.Info("hello").Send()
+			if _, err := ParseLevel("info"); err != nil {
+				t.Error(err)
+				return
+			}
+			_ = Levels()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			if err := RegisterLevel(Level(90), LevelConfig{Name: "AUDIT", Icon: "A"}); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	cfg, ok := LookupLevel(Level(90))
+	if !ok || cfg.Name != "AUDIT" {
+		t.Fatalf("LookupLevel(90) = %+v, %v", cfg, ok)
+	}
+	if level, err := ParseLevel("audit"); err != nil || level != Level(90) {
+		t.Fatalf("ParseLevel(audit) = %v, %v", level, err)
+	}
+	if err := RegisterLevel(Level(91), LevelConfig{Name: "Info"}); err == nil {
+		t.Fatal("RegisterLevel accepted a duplicate name")
+	}
+}
+
+func TestDefaultLevelConfigs(t *testing.T) {
+	for _, l := range []Level{TraceLevel, InfoLevel, SuccessLevel, PanicLevel} {
+		cfg, _ := LookupLevel(l)
+		if got := DefaultLevelConfigs[l]; got != cfg {
+			t.Errorf("DefaultLevelConfigs[%v] = %+v, want %+v", l, got, cfg)
+		}
+	}
+}
@@ -2,39 +2,90 @@ package aurora
 
 import (
 	"context"
-	"fmt"
 	"io"
-	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/Summaw/aurora/pkg/color"
+	"github.com/Summaw/aurora/pkg/format"
 )
 
 type Logger struct {
 	config *Config
-	mu     sync.Mutex
+	mu     *sync.Mutex
 	fields []Field
+	name   string
 }
 
 func (l *Logger) SetLevel(level Level) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	l.config.Level = level
+	l.stopLevelTimer()
+	l.config.setLevel(level)
+}
+
+func (l *Logger) GetLevel() Level {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.config.Level
+}
+
+func (l *Logger) SetLevelFor(level Level, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	previous := l.config.Level
+	if l.config.levelTimer != nil {
+		previous = l.config.levelBase
+		l.stopLevelTimer()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.config.levelTimer != timer {
+			return
+		}
+		l.config.setLevel(previous)
+		l.config.levelTimer = nil
+		l.config.levelExpiry = time.Time{}
+	})
+
+	l.config.setLevel(level)
+	l.config.levelBase = previous
+	l.config.levelTimer = timer
+	l.config.levelExpiry = time.Now().Add(ttl)
+}
+
+func (l *Logger) LevelExpiry() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.config.levelExpiry
+}
+
+func (l *Logger) stopLevelTimer() {
+	if l.config.levelTimer != nil {
+		l.config.levelTimer.Stop()
+		l.config.levelTimer = nil
+		l.config.levelExpiry = time.Time{}
+	}
 }
 
 func (l *Logger) SetOutput(w io.Writer) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.config.Output = w
+	l.config.refreshOutput()
 }
 
 func (l *Logger) SetTimeFormat(tf string) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.config.TimeFormat = tf
+	l.config.refreshOutput()
 }
 
 func (l *Logger) EnableCaller(enabled bool) {
@@ -43,19 +94,99 @@ func (l *Logger) EnableCaller(enabled bool) {
 	l.config.ShowCaller = enabled
 }
 
-func (l *Logger) newEntry(level Level, msg string) *Entry {
-	if level < l.config.Level {
-		return &Entry{logger: l, discard: true}
+func (l *Logger) AddSink(s Sink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	sinks := l.config.Sinks
+	l.config.setSinks(append(sinks[:len(sinks):len(sinks)], s))
+}
+
+func (l *Logger) sinks() []Sink {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.config.Sinks) == 0 {
+		return []Sink{NewSink(l.config.Output, TraceLevel, l.formatter())}
 	}
+	return append([]Sink(nil), l.config.Sinks...)
+}
+
+func (l *Logger) AddHook(h Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	hooks := l.config.Hooks
+	l.config.setHooks(append(hooks[:len(hooks):len(hooks)], h))
+}
 
-	entry := &Entry{
-		logger:    l,
-		Level:     level,
-		Message:   msg,
-		Timestamp: time.Now(),
-		Fields:    append([]Field{}, l.fields...),
-		discard:   false,
+func (l *Logger) Flush() error {
+	if l.config.dedup != nil {
+		l.config.dedup.expire()
 	}
+	sinks := l.sinks()
+
+	var firstErr error
+	for _, sink := range sinks {
+		if f, ok := sink.(Flusher); ok {
+			if err := f.Flush(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (l *Logger) Close() error {
+	if l.config.dedup != nil {
+		l.config.dedup.expire()
+	}
+	sinks := l.sinks()
+
+	var firstErr error
+	for _, sink := range sinks {
+		var err error
+		if c, ok := sink.(Closer); ok {
+			err = c.Close()
+		} else if f, ok := sink.(Flusher); ok {
+			err = f.Flush()
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (l *Logger) level() Level {
+	return l.config.loadLevel(l.name)
+}
+
+func (l *Logger) enabled(level Level) bool {
+	if level < l.level() {
+		return false
+	}
+	sinks := l.config.loadSinks()
+	if len(sinks) == 0 {
+		return true
+	}
+	for _, sink := range sinks {
+		if sink.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Logger) newEntry(level Level, msg string) *Entry {
+	if !l.enabled(level) {
+		return discardEntry
+	}
+
+	entry := getEntry()
+	entry.logger = l
+	entry.Level = level
+	entry.Message = msg
+	entry.Timestamp = time.Now()
+	entry.Fields = append(entry.Fields, l.fields...)
 
 	if l.config.ShowCaller {
 		entry.Caller = getCaller(l.config.CallerDepth)
@@ -64,6 +195,10 @@ func (l *Logger) newEntry(level Level, msg string) *Entry {
 	return entry
 }
 
+func (l *Logger) Log(level Level, msg string) *Entry {
+	return l.newEntry(level, msg)
+}
+
 func (l *Logger) Trace(msg string) *Entry {
 	return l.newEntry(TraceLevel, msg)
 }
@@ -90,28 +225,30 @@ func (l *Logger) Error(msg string) *Entry {
 
 func (l *Logger) Fatal(msg string) *Entry {
 	entry := l.newEntry(FatalLevel, msg)
-	entry.fatal = true
+	if !entry.discard {
+		entry.fatal = true
+	}
 	return entry
 }
 
 func (l *Logger) Panic(msg string) *Entry {
 	entry := l.newEntry(PanicLevel, msg)
-	entry.doPanic = true
+	if !entry.discard {
+		entry.doPanic = true
+	}
 	return entry
 }
 
 func (l *Logger) WithFields(fields F) *Entry {
-	entry := l.newEntry(InfoLevel, "")
-	for k, v := range fields {
-		entry.Fields = append(entry.Fields, Field{Key: k, Value: v})
-	}
-	return entry
+	return l.newEntry(InfoLevel, "").WithFields(fields)
 }
 
 func (l *Logger) With(key string, value any) *Logger {
 	newLogger := &Logger{
 		config: l.config,
-		fields: append(l.fields, Field{Key: key, Value: value}),
+		mu:     l.mu,
+		fields: append(l.fields[:len(l.fields):len(l.fields)], format.Any(key, value)),
+		name:   l.name,
 	}
 	return newLogger
 }
@@ -119,17 +256,30 @@ func (l *Logger) With(key string, value any) *Logger {
 func (l *Logger) Ctx(ctx context.Context) *Logger {
 	newLogger := &Logger{
 		config: l.config,
+		mu:     l.mu,
 		fields: append([]Field{}, l.fields...),
+		name:   l.name,
 	}
+	newLogger.fields = append(newLogger.fields, l.contextFields(ctx)...)
+	return newLogger
+}
 
-	if reqID := ctx.Value("request_id"); reqID != nil {
-		newLogger.fields = append(newLogger.fields, Field{Key: "request_id", Value: reqID})
-	}
-	if traceID := ctx.Value("trace_id"); traceID != nil {
-		newLogger.fields = append(newLogger.fields, Field{Key: "trace_id", Value: traceID})
+func (l *Logger) SetFormatter(f Formatter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config.Formatter = f
+	l.config.refreshOutput()
+}
+
+func (l *Logger) formatter() Formatter {
+	if l.config.Formatter != nil {
+		return l.config.Formatter
 	}
+	return format.Pretty{TimeFormat: l.config.TimeFormat, NoColor: l.config.NoColor}
+}
 
-	return newLogger
+func (l *Logger) format(dst []byte, r *Record) []byte {
+	return l.config.output.Format(dst, r)
 }
 
 func (l *Logger) write(entry *Entry) {
@@ -137,126 +287,70 @@ func (l *Logger) write(entry *Entry) {
 		return
 	}
 
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	var output string
-	if l.config.JSONOutput {
-		output = l.formatJSON(entry)
-	} else {
-		output = l.formatPretty(entry)
+	critical := entry.fatal || entry.doPanic
+	redactor := l.config.Redactor
+	if redactor != nil {
+		entry.Message = redactor.RedactString(entry.Message)
+		redactor.Redact(entry.Fields)
 	}
 
-	l.config.Output.Write([]byte(output))
-
-	if entry.fatal {
-		os.Exit(1)
+	msg, n := entry.Message, len(entry.Fields)
+	keep := l.fireHooks(entry)
+	if !keep && !critical {
+		putEntry(entry)
+		return
 	}
-
-	if entry.doPanic {
-		panic(entry.Message)
+	if !critical && l.config.Sampler != nil && !l.config.Sampler.Sample(entry.Level, entry.Message) {
+		putEntry(entry)
+		return
 	}
-}
-
-func (l *Logger) formatPretty(entry *Entry) string {
-	var sb strings.Builder
 
-	levelCfg := DefaultLevelConfigs[entry.Level]
-	timeStr := entry.Timestamp.Format(l.config.TimeFormat)
-
-	sb.WriteString("\n  ")
-	sb.WriteString(color.Colorize(timeStr, color.DimGray))
-	sb.WriteString("  ")
-
-	if levelCfg.Bold {
-		sb.WriteString(color.ColorizeBold(levelCfg.Icon+" "+levelCfg.Name, levelCfg.Color))
-	} else {
-		sb.WriteString(color.Colorize(levelCfg.Icon+" "+levelCfg.Name, levelCfg.Color))
+	if redactor != nil {
+		if entry.Message != msg {
+			entry.Message = redactor.RedactString(entry.Message)
+		}
+		if len(entry.Fields) > n {
+			redactor.Redact(entry.Fields[n:])
+		}
 	}
 
-	sb.WriteString("  ")
-	sb.WriteString(entry.Message)
-	sb.WriteString("\n")
-
-	if len(entry.Fields) > 0 {
-		for i, field := range entry.Fields {
-			prefix := "├─"
-			if i == len(entry.Fields)-1 && entry.Caller == "" {
-				prefix = "└─"
-			}
-
-			sb.WriteString("            ")
-			sb.WriteString(color.Colorize(prefix, color.DimGray))
-			sb.WriteString(" ")
-			sb.WriteString(color.Colorize(field.Key+":", color.Gray))
-			sb.WriteString(" ")
-			sb.WriteString(fmt.Sprintf("%v", field.Value))
-			sb.WriteString("\n")
+	if keep {
+		l.mu.Lock()
+		rec := entry.record()
+		if critical || l.config.dedup == nil || l.config.dedup.admit(l, rec) {
+			l.dispatch(rec)
 		}
+		l.mu.Unlock()
+		l.afterHooks(entry)
 	}
 
-	if entry.Caller != "" {
-		sb.WriteString("            ")
-		sb.WriteString(color.Colorize("└─", color.DimGray))
-		sb.WriteString(" ")
-		sb.WriteString(color.Colorize("at:", color.Gray))
-		sb.WriteString(" ")
-		sb.WriteString(color.Colorize(entry.Caller, color.DimGray))
-		sb.WriteString("\n")
+	if entry.fatal {
+		l.exit(1)
+		return
 	}
 
-	return sb.String()
-}
-
-func (l *Logger) formatJSON(entry *Entry) string {
-	var sb strings.Builder
-
-	sb.WriteString(`{"timestamp":"`)
-	sb.WriteString(entry.Timestamp.Format(time.RFC3339Nano))
-	sb.WriteString(`","level":"`)
-	sb.WriteString(entry.Level.String())
-	sb.WriteString(`","message":"`)
-	sb.WriteString(escapeJSON(entry.Message))
-	sb.WriteString(`"`)
-
-	for _, field := range entry.Fields {
-		sb.WriteString(`,"`)
-		sb.WriteString(field.Key)
-		sb.WriteString(`":`)
-		sb.WriteString(formatJSONValue(field.Value))
+	if entry.doPanic {
+		l.Flush()
+		panic(&PanicError{Entry: entry})
 	}
 
-	if entry.Caller != "" {
-		sb.WriteString(`,"caller":"`)
-		sb.WriteString(entry.Caller)
-		sb.WriteString(`"`)
+	putEntry(entry)
+}
+
+func (l *Logger) dispatch(rec *Record) {
+	if len(l.config.Sinks) == 0 {
+		buf := getBuffer()
+		*buf = l.format(*buf, rec)
+		l.config.Output.Write(*buf)
+		putBuffer(buf)
+		return
 	}
 
-	sb.WriteString("}\n")
-	return sb.String()
-}
-
-func escapeJSON(s string) string {
-	s = strings.ReplaceAll(s, `\`, `\\`)
-	s = strings.ReplaceAll(s, `"`, `\"`)
-	s = strings.ReplaceAll(s, "\n", `\n`)
-	s = strings.ReplaceAll(s, "\r", `\r`)
-	s = strings.ReplaceAll(s, "\t", `\t`)
-	return s
-}
-
-func formatJSONValue(v any) string {
-	switch val := v.(type) {
-	case string:
-		return `"` + escapeJSON(val) + `"`
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		return fmt.Sprintf("%d", val)
-	case float32, float64:
-		return fmt.Sprintf("%g", val)
-	case bool:
-		return fmt.Sprintf("%t", val)
-	default:
-		return `"` + escapeJSON(fmt.Sprintf("%v", val)) + `"`
+	level := Level(rec.Level.Value)
+	for _, sink := range l.config.Sinks {
+		if sink.Enabled(level) {
+			sink.Write(rec)
+		}
 	}
 }
 
@@ -266,10 +360,9 @@ func getCaller(depth int) string {
 		return ""
 	}
 
-	parts := strings.Split(file, "/")
-	if len(parts) > 0 {
-		file = parts[len(parts)-1]
+	if i := strings.LastIndexByte(file, '/'); i >= 0 {
+		file = file[i+1:]
 	}
 
-	return fmt.Sprintf("%s:%d", file, line)
+	return file + ":" + strconv.Itoa(line)
 }
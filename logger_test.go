@@ -0,0 +1,105 @@
+package aurora
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+func TestAddSinkConcurrent(t *testing.T) {
+	log := New(WithSinks(NewSink(io.Discard, ErrorLevel, format.JSON{})))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			log.Info("hello").Int("i", i).Send()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			log.AddSink(NewSink(io.Discard, TraceLevel, format.JSON{}))
+		}
+	}()
+	wg.Wait()
+
+	if got := len(log.sinks()); got != 101 {
+		t.Fatalf("sinks = %d, want 101", got)
+	}
+}
+
+func TestSetLevelForRevertConcurrent(t *testing.T) {
+	log := New(WithOutput(io.Discard), WithJSON(true), WithLevel(InfoLevel))
+	db := log.Named("db")
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				log.Info("hello").Send()
+				db.Debug("query").Send()
+			}
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		log.SetLevelFor(DebugLevel, time.Millisecond)
+		if err := log.SetLevelRules("db=trace"); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !log.LevelExpiry().IsZero() {
+		if time.Now().After(deadline) {
+			t.Fatal("level override did not expire")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(stop)
+	wg.Wait()
+
+	if got := log.GetLevel(); got != InfoLevel {
+		t.Fatalf("level after revert = %v, want %v", got, InfoLevel)
+	}
+	if log.enabled(DebugLevel) {
+		t.Fatal("debug still enabled after revert")
+	}
+	if !db.enabled(TraceLevel) {
+		t.Fatal("level rule for db not applied")
+	}
+}
+
+func TestJSONOutputCompat(t *testing.T) {
+	var buf strings.Builder
+	legacy := func(c *Config) { c.JSONOutput = true }
+	New(WithOutput(&buf), legacy).Info("hello").Send()
+	if !strings.HasPrefix(buf.String(), `{"timestamp":`) {
+		t.Fatalf("JSONOutput was ignored: %q", buf.String())
+	}
+
+	buf.Reset()
+	New(WithOutput(&buf), legacy, WithFormatter(format.Logfmt{})).Info("hello").Send()
+	if !strings.HasPrefix(buf.String(), "time=") {
+		t.Fatalf("Formatter did not take precedence: %q", buf.String())
+	}
+
+	buf.Reset()
+	New(WithOutput(&buf), WithJSON(true), WithJSON(false), WithNoColor(true)).Info("hello").Send()
+	if strings.HasPrefix(buf.String(), "{") {
+		t.Fatalf("WithJSON(false) left JSON output on: %q", buf.String())
+	}
+}
@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Summaw/aurora"
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type Config struct {
+	Message         string
+	StatusLevel     func(status int) aurora.Level
+	SkipPaths       []string
+	Filters         []Filter
+	SlowThreshold   time.Duration
+	SlowLevel       aurora.Level
+	LogQuery        bool
+	LogUserAgent    bool
+	LogReferer      bool
+	RequestHeaders  []string
+	ResponseHeaders []string
+	Redactor        *aurora.Redactor
+}
+
+var defaultRedactor = aurora.DefaultRedactor()
+
+// unknownBytes marks an access log whose response size the framework does not
+// report, so no bytes field is written.
+const unknownBytes = -1
+
+func DefaultStatusLevel(status int) aurora.Level {
+	switch {
+	case status >= 500:
+		return aurora.ErrorLevel
+	case status >= 400:
+		return aurora.WarnLevel
+	default:
+		return aurora.InfoLevel
+	}
+}
+
+func StatusLevels(levels map[int]aurora.Level) func(status int) aurora.Level {
+	return func(status int) aurora.Level {
+		if level, ok := levels[status]; ok {
+			return level
+		}
+		if level, ok := levels[status/100]; ok {
+			return level
+		}
+		return DefaultStatusLevel(status)
+	}
+}
+
+func (c Config) withDefaults() Config {
+	if c.Message == "" {
+		c.Message = "HTTP Request"
+	}
+	if c.StatusLevel == nil {
+		c.StatusLevel = DefaultStatusLevel
+	}
+	if c.SlowLevel == 0 {
+		c.SlowLevel = aurora.WarnLevel
+	}
+	if c.Redactor == nil {
+		c.Redactor = defaultRedactor
+	}
+	return c
+}
+
+type accessLog struct {
+	method    string
+	path      string
+	query     string
+	proto     string
+	ip        string
+	userAgent string
+	referer   string
+	status    int
+	bytes     int64
+	latency   time.Duration
+
+	requestHeader  func(name string) string
+	responseHeader func(name string) string
+}
+
+func (c *Config) skip(p string) bool {
+	return matchPath(c.SkipPaths, p)
+}
+
+func (c *Config) filter(r *http.Request) bool {
+	if c.skip(r.URL.Path) {
+		return false
+	}
+	for _, filter := range c.Filters {
+		if !filter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Config) log(log *aurora.Logger, a *accessLog) {
+	level := c.StatusLevel(a.status)
+	slow := c.SlowThreshold > 0 && a.latency >= c.SlowThreshold
+	if slow && level < c.SlowLevel {
+		level = c.SlowLevel
+	}
+
+	entry := log.Log(level, c.Message).
+		Str("method", a.method).
+		Str("path", a.path)
+
+	if c.LogQuery && a.query != "" {
+		entry = entry.Str("query", c.redactQuery(a.query))
+	}
+	if a.proto != "" {
+		entry = entry.Str("proto", a.proto)
+	}
+
+	entry = entry.Int("status", a.status)
+	if a.bytes != unknownBytes {
+		entry = entry.Int64("bytes", a.bytes)
+	}
+	entry = entry.Dur("latency", a.latency)
+	if slow {
+		entry = entry.Bool("slow", true)
+	}
+	entry = entry.Str("ip", a.ip)
+
+	if c.LogUserAgent && a.userAgent != "" {
+		entry = entry.Str("user_agent", a.userAgent)
+	}
+	if c.LogReferer && a.referer != "" {
+		entry = entry.Str("referer", a.referer)
+	}
+	if fields := c.headers(c.RequestHeaders, a.requestHeader); len(fields) > 0 {
+		entry = entry.Field("request_headers", fields)
+	}
+	if fields := c.headers(c.ResponseHeaders, a.responseHeader); len(fields) > 0 {
+		entry = entry.Field("response_headers", fields)
+	}
+
+	entry.Send()
+}
+
+func (c *Config) headers(names []string, get func(string) string) []aurora.Field {
+	if len(names) == 0 || get == nil {
+		return nil
+	}
+
+	var fields []aurora.Field
+	for _, name := range names {
+		if v := get(name); v != "" {
+			fields = append(fields, format.String(strings.ToLower(name), v))
+		}
+	}
+	c.Redactor.Redact(fields)
+	return fields
+}
+
+// redactQuery masks the values of query parameters whose names match the
+// redactor's keys, keeping the parameter order and encoding of the rest, and
+// then applies the value patterns to the whole string.
+func (c *Config) redactQuery(query string) string {
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		key, value, ok := strings.Cut(param, "=")
+		name, err := url.QueryUnescape(key)
+		if !ok || err != nil || !c.Redactor.MatchKey(name) {
+			continue
+		}
+		if v, err := url.QueryUnescape(value); err == nil {
+			value = v
+		}
+		fields := []aurora.Field{format.String(name, value)}
+		c.Redactor.Redact(fields)
+		params[i] = key + "=" + string(fields[0].AppendText(nil))
+	}
+	return c.Redactor.RedactString(strings.Join(params, "&"))
+}
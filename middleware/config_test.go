@@ -0,0 +1,284 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Summaw/aurora"
+	"github.com/Summaw/aurora/auroratest"
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+func serve(h http.Handler, target string, header http.Header) {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	h.ServeHTTP(httptest.NewRecorder(), r)
+}
+
+func statusHandler(status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(status)
+		w.Write([]byte("ok"))
+	})
+}
+
+func TestStatusLevels(t *testing.T) {
+	levels := StatusLevels(map[int]aurora.Level{
+		404: aurora.DebugLevel,
+		2:   aurora.TraceLevel,
+		503: aurora.WarnLevel,
+	})
+	tests := []struct {
+		status int
+		want   aurora.Level
+	}{
+		{200, aurora.TraceLevel},
+		{204, aurora.TraceLevel},
+		{301, aurora.InfoLevel},
+		{404, aurora.DebugLevel},
+		{403, aurora.WarnLevel},
+		{503, aurora.WarnLevel},
+		{500, aurora.ErrorLevel},
+	}
+	for _, tt := range tests {
+		if got := levels(tt.status); got != tt.want {
+			t.Errorf("status %d: level %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestHTTPConfigStatusLevel(t *testing.T) {
+	log, rec := auroratest.NewLogger(t, auroratest.WithLevel(aurora.TraceLevel))
+	h := HTTPWithConfig(log, Config{
+		Message:     "req",
+		StatusLevel: StatusLevels(map[int]aurora.Level{404: aurora.DebugLevel}),
+	})(statusHandler(http.StatusNotFound))
+
+	serve(h, "/missing", nil)
+
+	rec.RequireEntry(aurora.DebugLevel, "req", format.Any("status", 404), format.Any("bytes", int64(2)))
+}
+
+func TestHTTPConfigSkipPaths(t *testing.T) {
+	log, rec := auroratest.NewLogger(t)
+	h := HTTPWithConfig(log, Config{SkipPaths: []string{"/healthz", "/static/*"}})(statusHandler(http.StatusOK))
+
+	serve(h, "/healthz", nil)
+	serve(h, "/static/app.js", nil)
+	serve(h, "/static/css/app.css", nil)
+	serve(h, "/api", nil)
+
+	if rec.Len() != 2 {
+		t.Fatalf("logged %d entries, want 2:\n%v", rec.Len(), rec.Entries())
+	}
+	rec.RequireEntry(aurora.InfoLevel, "HTTP Request", format.Any("path", "/api"))
+	rec.RequireEntry(aurora.InfoLevel, "HTTP Request", format.Any("path", "/static/css/app.css"))
+}
+
+func TestHTTPConfigSlow(t *testing.T) {
+	log, rec := auroratest.NewLogger(t)
+	h := HTTPWithConfig(log, Config{SlowThreshold: time.Nanosecond, SlowLevel: aurora.ErrorLevel})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(time.Millisecond)
+		}))
+	serve(h, "/slow", nil)
+	rec.RequireEntry(aurora.ErrorLevel, "HTTP Request", format.Any("slow", true))
+
+	rec.Reset()
+	h = HTTPWithConfig(log, Config{SlowThreshold: time.Hour})(statusHandler(http.StatusOK))
+	serve(h, "/fast", nil)
+	e := rec.RequireEntry(aurora.InfoLevel, "HTTP Request")
+	if _, ok := e.Field("slow"); ok {
+		t.Fatalf("fast request marked slow: %v", e)
+	}
+
+	rec.Reset()
+	h = HTTPWithConfig(log, Config{SlowThreshold: time.Nanosecond})(statusHandler(http.StatusInternalServerError))
+	serve(h, "/broken", nil)
+	rec.RequireEntry(aurora.ErrorLevel, "HTTP Request", format.Any("slow", true))
+}
+
+func TestHTTPConfigQuery(t *testing.T) {
+	log, rec := auroratest.NewLogger(t)
+	h := HTTPWithConfig(log, Config{LogQuery: true})(statusHandler(http.StatusOK))
+
+	serve(h, "/login?user=bob&Password=hunter2&api%5Fkey=k-1&card=4111111111111111&since=1700000000000&flag", nil)
+
+	rec.RequireEntry(aurora.InfoLevel, "HTTP Request",
+		format.Any("query", "user=bob&Password=[REDACTED]&api%5Fkey=[REDACTED]&card=[REDACTED]&since=1700000000000&flag"))
+
+	rec.Reset()
+	h = HTTP(log)(statusHandler(http.StatusOK))
+	serve(h, "/login?password=hunter2", nil)
+	e := rec.RequireEntry(aurora.InfoLevel, "HTTP Request")
+	if _, ok := e.Field("query"); ok {
+		t.Fatalf("query logged without LogQuery: %v", e)
+	}
+}
+
+func TestHTTPConfigHeaders(t *testing.T) {
+	log, rec := auroratest.NewLogger(t)
+	h := HTTPWithConfig(log, Config{
+		LogUserAgent:    true,
+		LogReferer:      true,
+		RequestHeaders:  []string{"Authorization", "Accept", "X-Missing"},
+		ResponseHeaders: []string{"Content-Type"},
+	})(statusHandler(http.StatusOK))
+
+	serve(h, "/", http.Header{
+		"Authorization": {"Bearer abc"},
+		"Accept":        {"text/plain"},
+		"User-Agent":    {"curl/8"},
+		"Referer":       {"https://example.com/"},
+	})
+
+	e := rec.RequireEntry(aurora.InfoLevel, "HTTP Request",
+		format.Any("user_agent", "curl/8"),
+		format.Any("referer", "https://example.com/"))
+
+	v, _ := e.Field("request_headers")
+	want := []aurora.Field{format.String("authorization", "[REDACTED]"), format.String("accept", "text/plain")}
+	if got, _ := v.([]aurora.Field); !sameFields(got, want) {
+		t.Fatalf("request_headers = %v, want %v", v, want)
+	}
+	v, _ = e.Field("response_headers")
+	want = []aurora.Field{format.String("content-type", "text/plain")}
+	if got, _ := v.([]aurora.Field); !sameFields(got, want) {
+		t.Fatalf("response_headers = %v, want %v", v, want)
+	}
+}
+
+func sameFields(got, want []aurora.Field) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i].Key != want[i].Key || got[i].Any() != want[i].Any() {
+			return false
+		}
+	}
+	return true
+}
+
+type fakeFiber struct {
+	method, url string
+	header      map[string]string
+	status      int
+}
+
+func (c *fakeFiber) Method() string { return c.method }
+func (c *fakeFiber) IP() string     { return "10.0.0.1" }
+func (c *fakeFiber) Next() error    { return nil }
+
+func (c *fakeFiber) Path() string {
+	p, _, _ := strings.Cut(c.url, "?")
+	return p
+}
+
+func (c *fakeFiber) OriginalURL() string { return c.url }
+func (c *fakeFiber) Protocol() string    { return "HTTP/1.0" }
+
+func (c *fakeFiber) Get(key string, defaultValue ...string) string {
+	if v, ok := c.header[key]; ok {
+		return v
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return ""
+}
+
+func (c *fakeFiber) Response() FiberResponse { return fakeFiberResponse{c.status} }
+
+type fakeFiberResponse struct{ status int }
+
+func (r fakeFiberResponse) StatusCode() int          { return r.status }
+func (r fakeFiberResponse) Header(key string) string { return "resp-" + key }
+
+func TestFiberWithConfig(t *testing.T) {
+	log, rec := auroratest.NewLogger(t)
+	mw := FiberWithConfig(log, Config{
+		SkipPaths:       []string{"/healthz"},
+		LogQuery:        true,
+		LogUserAgent:    true,
+		RequestHeaders:  []string{"Authorization"},
+		ResponseHeaders: []string{"X-Id"},
+	})
+
+	mw(&fakeFiber{method: "GET", url: "/healthz", status: 200})
+	mw(&fakeFiber{
+		method: "POST",
+		url:    "/users?token=t-1&page=2",
+		header: map[string]string{"User-Agent": "fiber-test", "Authorization": "Basic xyz"},
+		status: 503,
+	})
+
+	if rec.Len() != 1 {
+		t.Fatalf("logged %d entries, want 1:\n%v", rec.Len(), rec.Entries())
+	}
+	e := rec.RequireEntry(aurora.ErrorLevel, "HTTP Request",
+		format.Any("method", "POST"),
+		format.Any("path", "/users"),
+		format.Any("status", 503),
+		format.Any("ip", "10.0.0.1"),
+		format.Any("proto", "HTTP/1.0"),
+		format.Any("user_agent", "fiber-test"),
+		format.Any("query", "token=[REDACTED]&page=2"))
+	if _, ok := e.Field("bytes"); ok {
+		t.Fatalf("fiber entry has a bytes field: %v", e)
+	}
+	v, _ := e.Field("request_headers")
+	if got, _ := v.([]aurora.Field); !sameFields(got, []aurora.Field{format.String("authorization", "[REDACTED]")}) {
+		t.Fatalf("request_headers = %v", v)
+	}
+	v, _ = e.Field("response_headers")
+	if got, _ := v.([]aurora.Field); !sameFields(got, []aurora.Field{format.String("x-id", "resp-X-Id")}) {
+		t.Fatalf("response_headers = %v", v)
+	}
+}
+
+func TestLogRequestConfig(t *testing.T) {
+	log, rec := auroratest.NewLogger(t)
+	h := NewHTTPHandlerWithConfig(log, Config{
+		Message:       "access",
+		SkipPaths:     []string{"/metrics"},
+		StatusLevel:   StatusLevels(map[int]aurora.Level{4: aurora.InfoLevel}),
+		SlowThreshold: time.Second,
+	})
+
+	h.LogRequest("GET", "/metrics", "::1", 200, time.Millisecond)
+	h.LogRequest("GET", "/thing", "::1", 404, 2*time.Second)
+
+	if rec.Len() != 1 {
+		t.Fatalf("logged %d entries, want 1:\n%v", rec.Len(), rec.Entries())
+	}
+	e := rec.RequireEntry(aurora.WarnLevel, "access",
+		format.Any("path", "/thing"),
+		format.Any("status", 404),
+		format.Any("slow", true))
+	if _, ok := e.Field("bytes"); ok {
+		t.Fatalf("LogRequest entry has a bytes field: %v", e)
+	}
+}
+
+func TestHTTPCommonLog(t *testing.T) {
+	var buf strings.Builder
+	log := aurora.New(aurora.WithOutput(&buf), aurora.WithFormatter(format.CommonLog{}))
+	h := HTTP(log)(statusHandler(http.StatusOK))
+
+	r := httptest.NewRequest(http.MethodGet, "/users?page=2", nil)
+	r.Proto = "HTTP/2.0"
+	r.RemoteAddr = "10.0.0.1:1234"
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	_, line, _ := strings.Cut(buf.String(), "] ")
+	if want := `"GET /users HTTP/2.0" 200 2` + "\n"; line != want {
+		t.Fatalf("got %q, want %q", line, want)
+	}
+}
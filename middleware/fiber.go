@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"strings"
 	"time"
 
 	"github.com/Summaw/aurora"
@@ -18,30 +19,61 @@ type FiberResponse interface {
 	StatusCode() int
 }
 
+type FiberRequest interface {
+	Get(key string, defaultValue ...string) string
+	OriginalURL() string
+}
+
+type FiberProtocol interface {
+	Protocol() string
+}
+
+type FiberResponseHeader interface {
+	Header(key string) string
+}
+
 func Fiber(log *aurora.Logger) func(FiberContext) error {
+	return FiberWithConfig(log, Config{})
+}
+
+func FiberWithConfig(log *aurora.Logger, cfg Config) func(FiberContext) error {
+	cfg = cfg.withDefaults()
+
 	return func(c FiberContext) error {
 		start := time.Now()
 
 		err := c.Next()
 
-		status := c.Response().StatusCode()
-		latency := time.Since(start)
+		if cfg.skip(c.Path()) {
+			return err
+		}
+
+		resp := c.Response()
+		a := &accessLog{
+			method:  c.Method(),
+			path:    c.Path(),
+			ip:      c.IP(),
+			status:  resp.StatusCode(),
+			bytes:   unknownBytes,
+			latency: time.Since(start),
+		}
 
-		entry := log.Info("HTTP Request")
-		if status >= 400 {
-			entry = log.Warn("HTTP Request")
+		if req, ok := c.(FiberRequest); ok {
+			if _, query, found := strings.Cut(req.OriginalURL(), "?"); found {
+				a.query = query
+			}
+			a.userAgent = req.Get("User-Agent")
+			a.referer = req.Get("Referer")
+			a.requestHeader = func(name string) string { return req.Get(name) }
 		}
-		if status >= 500 {
-			entry = log.Error("HTTP Request")
+		if p, ok := c.(FiberProtocol); ok {
+			a.proto = p.Protocol()
+		}
+		if h, ok := resp.(FiberResponseHeader); ok {
+			a.responseHeader = h.Header
 		}
 
-		entry.
-			Str("method", c.Method()).
-			Str("path", c.Path()).
-			Int("status", status).
-			Dur("latency", latency).
-			Str("ip", c.IP()).
-			Send()
+		cfg.log(log, a)
 
 		return err
 	}
@@ -49,26 +81,29 @@ func Fiber(log *aurora.Logger) func(FiberContext) error {
 
 type HTTPHandler struct {
 	Logger *aurora.Logger
+	Config Config
 }
 
 func NewHTTPHandler(log *aurora.Logger) *HTTPHandler {
 	return &HTTPHandler{Logger: log}
 }
 
+func NewHTTPHandlerWithConfig(log *aurora.Logger, cfg Config) *HTTPHandler {
+	return &HTTPHandler{Logger: log, Config: cfg}
+}
+
 func (h *HTTPHandler) LogRequest(method, path, ip string, status int, latency time.Duration) {
-	entry := h.Logger.Info("HTTP Request")
-	if status >= 400 {
-		entry = h.Logger.Warn("HTTP Request")
-	}
-	if status >= 500 {
-		entry = h.Logger.Error("HTTP Request")
+	cfg := h.Config.withDefaults()
+	if cfg.skip(path) {
+		return
 	}
 
-	entry.
-		Str("method", method).
-		Str("path", path).
-		Int("status", status).
-		Dur("latency", latency).
-		Str("ip", ip).
-		Send()
+	cfg.log(h.Logger, &accessLog{
+		method:  method,
+		path:    path,
+		ip:      ip,
+		status:  status,
+		bytes:   unknownBytes,
+		latency: latency,
+	})
 }
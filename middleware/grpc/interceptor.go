@@ -0,0 +1,313 @@
+package auroragrpc
+
+import (
+	"context"
+	"io"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/Summaw/aurora"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const RequestIDMetadata = "x-request-id"
+
+type Config struct {
+	CodeLevel   func(code codes.Code) aurora.Level
+	SkipMethods []string
+}
+
+func DefaultCodeLevel(code codes.Code) aurora.Level {
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound,
+		codes.AlreadyExists, codes.Unauthenticated:
+		return aurora.InfoLevel
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted,
+		codes.FailedPrecondition, codes.Aborted, codes.OutOfRange, codes.Unavailable:
+		return aurora.WarnLevel
+	default:
+		return aurora.ErrorLevel
+	}
+}
+
+func (c Config) withDefaults() Config {
+	if c.CodeLevel == nil {
+		c.CodeLevel = DefaultCodeLevel
+	}
+	return c
+}
+
+func (c *Config) skip(method string) bool {
+	for _, pattern := range c.SkipMethods {
+		if pattern == method {
+			return true
+		}
+		if ok, _ := path.Match(pattern, method); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type call struct {
+	message  string
+	method   string
+	kind     string
+	start    time.Time
+	peer     string
+	sent     int64
+	received int64
+	stream   bool
+}
+
+func (c *Config) log(log *aurora.Logger, cl *call, err error) {
+	if c.skip(cl.method) {
+		return
+	}
+
+	code := status.Code(err)
+	entry := log.Log(c.CodeLevel(code), cl.message).
+		Str("method", cl.method).
+		Str("type", cl.kind).
+		Str("code", code.String()).
+		Dur("latency", time.Since(cl.start))
+
+	if cl.peer != "" {
+		entry = entry.Str("peer", cl.peer)
+	}
+	if cl.stream {
+		entry = entry.
+			Int64("msgs_sent", cl.sent).
+			Int64("msgs_received", cl.received)
+	}
+	if err != nil {
+		entry = entry.Err(err)
+	}
+
+	entry.Send()
+}
+
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+func streamKind(client, server bool) string {
+	switch {
+	case client && server:
+		return "bidi_stream"
+	case client:
+		return "client_stream"
+	case server:
+		return "server_stream"
+	default:
+		return "unary"
+	}
+}
+
+func serverContext(ctx context.Context, log *aurora.Logger) (context.Context, *aurora.Logger) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDMetadata); len(ids) > 0 && ids[0] != "" {
+			ctx = aurora.WithRequestID(ctx, ids[0])
+		}
+	}
+	reqLog := log.Ctx(ctx)
+	return aurora.NewContext(ctx, reqLog), reqLog
+}
+
+func UnaryServerInterceptor(log *aurora.Logger, cfg Config) grpc.UnaryServerInterceptor {
+	cfg = cfg.withDefaults()
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		cl := &call{
+			message: "gRPC Request",
+			method:  info.FullMethod,
+			kind:    "unary",
+			start:   time.Now(),
+			peer:    peerAddr(ctx),
+		}
+
+		ctx, reqLog := serverContext(ctx, log)
+		resp, err := handler(ctx, req)
+
+		cfg.log(reqLog, cl, err)
+		return resp, err
+	}
+}
+
+func StreamServerInterceptor(log *aurora.Logger, cfg Config) grpc.StreamServerInterceptor {
+	cfg = cfg.withDefaults()
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		cl := &call{
+			message: "gRPC Request",
+			method:  info.FullMethod,
+			kind:    streamKind(info.IsClientStream, info.IsServerStream),
+			start:   time.Now(),
+			peer:    peerAddr(ss.Context()),
+			stream:  true,
+		}
+
+		ctx, reqLog := serverContext(ss.Context(), log)
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx, call: cl})
+
+		cfg.log(reqLog, cl, err)
+		return err
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	call *call
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.call.sent++
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.call.received++
+	}
+	return err
+}
+
+func clientContext(ctx context.Context) context.Context {
+	if id, ok := ctx.Value(aurora.RequestIDKey).(string); ok && id != "" {
+		if md, ok := metadata.FromOutgoingContext(ctx); !ok || len(md.Get(RequestIDMetadata)) == 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDMetadata, id)
+		}
+	}
+	return ctx
+}
+
+func UnaryClientInterceptor(log *aurora.Logger, cfg Config) grpc.UnaryClientInterceptor {
+	cfg = cfg.withDefaults()
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		cl := &call{
+			message: "gRPC Call",
+			method:  method,
+			kind:    "unary",
+			start:   time.Now(),
+		}
+
+		var p peer.Peer
+		err := invoker(clientContext(ctx), method, req, reply, cc, append(opts, grpc.Peer(&p))...)
+		if p.Addr != nil {
+			cl.peer = p.Addr.String()
+		}
+
+		cfg.log(log.Ctx(ctx), cl, err)
+		return err
+	}
+}
+
+func StreamClientInterceptor(log *aurora.Logger, cfg Config) grpc.StreamClientInterceptor {
+	cfg = cfg.withDefaults()
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cl := &call{
+			message: "gRPC Call",
+			method:  method,
+			kind:    streamKind(desc.ClientStreams, desc.ServerStreams),
+			start:   time.Now(),
+			stream:  true,
+		}
+
+		cs, err := streamer(clientContext(ctx), desc, cc, method, opts...)
+		if err != nil {
+			cfg.log(log.Ctx(ctx), cl, err)
+			return nil, err
+		}
+
+		s := &clientStream{
+			ClientStream:  cs,
+			cfg:           &cfg,
+			log:           log.Ctx(ctx),
+			call:          cl,
+			serverStreams: desc.ServerStreams,
+			done:          make(chan struct{}),
+		}
+		go s.watch(ctx)
+		return s, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	cfg           *Config
+	log           *aurora.Logger
+	call          *call
+	serverStreams bool
+	mu            sync.Mutex
+	once          sync.Once
+	done          chan struct{}
+}
+
+func (s *clientStream) watch(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		s.finish(status.FromContextError(ctx.Err()).Err())
+	case <-s.done:
+	}
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.mu.Lock()
+		s.call.sent++
+		s.mu.Unlock()
+	} else if err != io.EOF {
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.mu.Lock()
+		s.call.received++
+		s.mu.Unlock()
+		if !s.serverStreams {
+			s.finish(nil)
+		}
+		return nil
+	}
+
+	if err == io.EOF {
+		s.finish(nil)
+	} else {
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *clientStream) finish(err error) {
+	s.once.Do(func() {
+		s.call.peer = peerAddr(s.ClientStream.Context())
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.cfg.log(s.log, s.call, err)
+		close(s.done)
+	})
+}
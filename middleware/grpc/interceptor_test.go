@@ -0,0 +1,328 @@
+package auroragrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Summaw/aurora"
+	"github.com/Summaw/aurora/auroratest"
+	"github.com/Summaw/aurora/pkg/format"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	return []byte(*v.(*string)), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	*v.(*string) = string(data)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "raw"
+}
+
+var echoDesc = grpc.ServiceDesc{
+	ServiceName: "test.Echo",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Unary", Handler: unaryHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "ServerStream", Handler: serverStreamHandler, ServerStreams: true},
+		{StreamName: "ClientStream", Handler: clientStreamHandler, ClientStreams: true},
+		{StreamName: "Bidi", Handler: bidiHandler, ServerStreams: true, ClientStreams: true},
+		{StreamName: "Hang", Handler: hangHandler, ServerStreams: true},
+	},
+}
+
+func unaryHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(string)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		msg := *req.(*string)
+		if msg == "fail" {
+			return nil, status.Error(codes.NotFound, "missing")
+		}
+		out := "echo " + msg
+		return &out, nil
+	}
+	if interceptor == nil {
+		return handler(ctx, in)
+	}
+	return interceptor(ctx, in, &grpc.UnaryServerInfo{FullMethod: "/test.Echo/Unary"}, handler)
+}
+
+func serverStreamHandler(srv any, stream grpc.ServerStream) error {
+	var in string
+	if err := stream.RecvMsg(&in); err != nil {
+		return err
+	}
+	for i := 0; i < 3; i++ {
+		out := in + strconv.Itoa(i)
+		if err := stream.SendMsg(&out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func clientStreamHandler(srv any, stream grpc.ServerStream) error {
+	n := 0
+	for {
+		var in string
+		err := stream.RecvMsg(&in)
+		if err == io.EOF {
+			out := strconv.Itoa(n)
+			return stream.SendMsg(&out)
+		}
+		if err != nil {
+			return err
+		}
+		n++
+	}
+}
+
+func bidiHandler(srv any, stream grpc.ServerStream) error {
+	for {
+		var in string
+		err := stream.RecvMsg(&in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.SendMsg(&in); err != nil {
+			return err
+		}
+	}
+}
+
+func hangHandler(srv any, stream grpc.ServerStream) error {
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+type harness struct {
+	conn      *grpc.ClientConn
+	serverRec *auroratest.Recorder
+	clientRec *auroratest.Recorder
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	serverLog, serverRec := auroratest.NewLogger(t)
+	clientLog, clientRec := auroratest.NewLogger(t)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnaryInterceptor(UnaryServerInterceptor(serverLog, Config{})),
+		grpc.StreamInterceptor(StreamServerInterceptor(serverLog, Config{})),
+	)
+	srv.RegisterService(&echoDesc, struct{}{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(clientLog, Config{})),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(clientLog, Config{})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &harness{conn: conn, serverRec: serverRec, clientRec: clientRec}
+}
+
+func (h *harness) stream(t *testing.T, ctx context.Context, name string) grpc.ClientStream {
+	t.Helper()
+	for i := range echoDesc.Streams {
+		if desc := &echoDesc.Streams[i]; desc.StreamName == name {
+			cs, err := h.conn.NewStream(ctx, desc, "/test.Echo/"+name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return cs
+		}
+	}
+	t.Fatalf("unknown stream %s", name)
+	return nil
+}
+
+func waitEntry(t *testing.T, rec *auroratest.Recorder, msg string, fields ...aurora.Field) auroratest.Entry {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if e, ok := rec.Find(aurora.InfoLevel, msg, fields...); ok {
+			return e
+		}
+		if time.Now().After(deadline) {
+			return rec.RequireEntry(aurora.InfoLevel, msg, fields...)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func field(key string, value any) aurora.Field {
+	return format.Any(key, value)
+}
+
+func TestUnary(t *testing.T) {
+	h := newHarness(t)
+	ctx := aurora.WithRequestID(context.Background(), "req-1")
+
+	in, out := "hi", ""
+	if err := h.conn.Invoke(ctx, "/test.Echo/Unary", &in, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != "echo hi" {
+		t.Fatalf("reply = %q", out)
+	}
+
+	waitEntry(t, h.clientRec, "gRPC Call",
+		field("method", "/test.Echo/Unary"), field("type", "unary"), field("code", "OK"))
+	e := waitEntry(t, h.serverRec, "gRPC Request",
+		field("method", "/test.Echo/Unary"), field("type", "unary"), field("code", "OK"))
+	if id, _ := e.Field("request_id"); id != "req-1" {
+		t.Fatalf("server request_id = %v, want req-1", id)
+	}
+
+	in = "fail"
+	err := h.conn.Invoke(context.Background(), "/test.Echo/Unary", &in, &out)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("err = %v, want NotFound", err)
+	}
+	waitEntry(t, h.clientRec, "gRPC Call", field("code", "NotFound"))
+	waitEntry(t, h.serverRec, "gRPC Request", field("code", "NotFound"))
+}
+
+func TestServerStream(t *testing.T) {
+	h := newHarness(t)
+	cs := h.stream(t, context.Background(), "ServerStream")
+
+	in := "n"
+	if err := cs.SendMsg(&in); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		var out string
+		err := cs.RecvMsg(&out)
+		if err == io.EOF {
+			if i != 3 {
+				t.Fatalf("received %d messages, want 3", i)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []aurora.Field{field("type", "server_stream"), field("code", "OK"), field("msgs_sent", 1), field("msgs_received", 3)}
+	waitEntry(t, h.clientRec, "gRPC Call", want...)
+	waitEntry(t, h.serverRec, "gRPC Request",
+		field("type", "server_stream"), field("code", "OK"), field("msgs_sent", 3), field("msgs_received", 1))
+}
+
+func TestClientStream(t *testing.T) {
+	h := newHarness(t)
+	cs := h.stream(t, context.Background(), "ClientStream")
+
+	for i := 0; i < 3; i++ {
+		in := strconv.Itoa(i)
+		if err := cs.SendMsg(&in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cs.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	var out string
+	if err := cs.RecvMsg(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out != "3" {
+		t.Fatalf("reply = %q, want 3", out)
+	}
+
+	// Generated CloseAndRecv stops after the single reply, so the call must
+	// be logged without waiting for io.EOF.
+	h.clientRec.RequireEntry(aurora.InfoLevel, "gRPC Call",
+		field("type", "client_stream"), field("code", "OK"), field("msgs_sent", 3), field("msgs_received", 1))
+	waitEntry(t, h.serverRec, "gRPC Request",
+		field("type", "client_stream"), field("code", "OK"), field("msgs_sent", 1), field("msgs_received", 3))
+}
+
+func TestBidiStream(t *testing.T) {
+	h := newHarness(t)
+	cs := h.stream(t, context.Background(), "Bidi")
+
+	for i := 0; i < 2; i++ {
+		in := strconv.Itoa(i)
+		if err := cs.SendMsg(&in); err != nil {
+			t.Fatal(err)
+		}
+		var out string
+		if err := cs.RecvMsg(&out); err != nil {
+			t.Fatal(err)
+		}
+		if out != in {
+			t.Fatalf("reply = %q, want %q", out, in)
+		}
+	}
+	if err := cs.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	var out string
+	if err := cs.RecvMsg(&out); err != io.EOF {
+		t.Fatalf("final RecvMsg = %v, want io.EOF", err)
+	}
+
+	waitEntry(t, h.clientRec, "gRPC Call",
+		field("type", "bidi_stream"), field("code", "OK"), field("msgs_sent", 2), field("msgs_received", 2))
+	waitEntry(t, h.serverRec, "gRPC Request",
+		field("type", "bidi_stream"), field("code", "OK"), field("msgs_sent", 2), field("msgs_received", 2))
+}
+
+func TestAbandonedStreamIsLogged(t *testing.T) {
+	h := newHarness(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cs := h.stream(t, ctx, "Hang")
+
+	in := "x"
+	if err := cs.SendMsg(&in); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	waitEntry(t, h.clientRec, "gRPC Call",
+		field("method", "/test.Echo/Hang"), field("type", "server_stream"), field("code", "Canceled"))
+	if n := h.clientRec.Len(); n != 1 {
+		t.Fatalf("client entries = %d, want 1", n)
+	}
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/Summaw/aurora"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+type Filter func(r *http.Request) bool
+
+func PathFilter(patterns ...string) Filter {
+	return func(r *http.Request) bool {
+		return matchPath(patterns, r.URL.Path)
+	}
+}
+
+func SkipPaths(patterns ...string) Filter {
+	return func(r *http.Request) bool {
+		return !matchPath(patterns, r.URL.Path)
+	}
+}
+
+func HeaderFilter(name string, values ...string) Filter {
+	return func(r *http.Request) bool {
+		got := r.Header.Values(name)
+		if len(values) == 0 {
+			return len(got) > 0
+		}
+		for _, g := range got {
+			for _, v := range values {
+				if g == v {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func matchPath(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if pattern == p {
+			return true
+		}
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func HTTP(log *aurora.Logger, filters ...Filter) func(http.Handler) http.Handler {
+	return HTTPWithConfig(log, Config{Filters: filters})
+}
+
+func HTTPWithConfig(log *aurora.Logger, cfg Config) func(http.Handler) http.Handler {
+	cfg = cfg.withDefaults()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			id := r.Header.Get(RequestIDHeader)
+			if id == "" || len(id) > 128 {
+				id = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, id)
+
+			ctx := aurora.WithRequestID(r.Context(), id)
+			reqLog := log.Ctx(ctx)
+			r = r.WithContext(aurora.NewContext(ctx, reqLog))
+
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+			defer func() {
+				if rec := recover(); rec != nil {
+					if rec == http.ErrAbortHandler {
+						panic(rec)
+					}
+					if !rw.wroteHeader {
+						rw.WriteHeader(http.StatusInternalServerError)
+					}
+					reqLog.Log(aurora.PanicLevel, "HTTP Handler Panic").
+						Any("panic", rec).
+						Str("method", r.Method).
+						Str("path", r.URL.Path).
+						Stack().
+						Send()
+				}
+
+				if !cfg.filter(r) {
+					return
+				}
+
+				cfg.log(reqLog, &accessLog{
+					method:         r.Method,
+					path:           r.URL.Path,
+					query:          r.URL.RawQuery,
+					proto:          r.Proto,
+					ip:             remoteIP(r),
+					userAgent:      r.UserAgent(),
+					referer:        r.Referer(),
+					status:         rw.status,
+					bytes:          rw.bytes,
+					latency:        time.Since(start),
+					requestHeader:  r.Header.Get,
+					responseHeader: rw.Header().Get,
+				})
+			}()
+
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if !w.wroteHeader {
+			w.wroteHeader = true
+		}
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("middleware: response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
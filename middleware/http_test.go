@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Summaw/aurora"
+	"github.com/Summaw/aurora/auroratest"
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+func TestHTTPPanicIsAccessLogged(t *testing.T) {
+	log, rec := auroratest.NewLogger(t)
+	h := HTTP(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/explode", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	rec.RequireEntry(aurora.PanicLevel, "HTTP Handler Panic", format.Any("path", "/explode"))
+	rec.RequireEntry(aurora.ErrorLevel, "HTTP Request",
+		format.Any("status", 500),
+		format.Any("path", "/explode"))
+}
+
+func TestHTTPPanicAfterWriteKeepsStatus(t *testing.T) {
+	log, rec := auroratest.NewLogger(t)
+	h := HTTP(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("late")
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/late", nil))
+
+	rec.RequireEntry(aurora.InfoLevel, "HTTP Request", format.Any("status", http.StatusAccepted))
+}
+
+func TestHTTPAbortHandlerRepanics(t *testing.T) {
+	log, rec := auroratest.NewLogger(t)
+	h := HTTP(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if recover() != http.ErrAbortHandler {
+			t.Fatal("ErrAbortHandler was not re-raised")
+		}
+		if rec.Len() != 0 {
+			t.Fatalf("aborted request logged %d entries", rec.Len())
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,100 @@
+package aurora
+
+import (
+	"fmt"
+	"strings"
+)
+
+type LevelRule struct {
+	Pattern string
+	Level   Level
+}
+
+func ParseLevelRules(spec string) ([]LevelRule, error) {
+	var rules []LevelRule
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		pattern, levelName, ok := strings.Cut(part, "=")
+		if !ok {
+			pattern, levelName = "*", part
+		}
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			return nil, fmt.Errorf("aurora: empty logger name in level rule %q", part)
+		}
+
+		level, err := ParseLevel(levelName)
+		if err != nil {
+			return nil, fmt.Errorf("%w in level rule %q", err, part)
+		}
+		rules = append(rules, LevelRule{Pattern: pattern, Level: level})
+	}
+	return rules, nil
+}
+
+func MustParseLevelRules(spec string) []LevelRule {
+	rules, err := ParseLevelRules(spec)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+func (r LevelRule) match(name string) (int, bool) {
+	switch {
+	case r.Pattern == "*":
+		return 0, true
+	case r.Pattern == name:
+		return len(r.Pattern) + 2, true
+	case strings.HasSuffix(r.Pattern, ".*"):
+		prefix := strings.TrimSuffix(r.Pattern, ".*")
+		if name == prefix || strings.HasPrefix(name, prefix+".") {
+			return len(prefix) + 1, true
+		}
+	}
+	return 0, false
+}
+
+func resolveLevel(rules []LevelRule, name string, fallback Level) Level {
+	best := -1
+	level := fallback
+	for _, rule := range rules {
+		if score, ok := rule.match(name); ok && score > best {
+			best = score
+			level = rule.Level
+		}
+	}
+	return level
+}
+
+func (l *Logger) Named(name string) *Logger {
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+	return &Logger{
+		config: l.config,
+		mu:     l.mu,
+		fields: l.fields,
+		name:   name,
+	}
+}
+
+func (l *Logger) Name() string {
+	return l.name
+}
+
+func (l *Logger) SetLevelRules(spec string) error {
+	rules, err := ParseLevelRules(spec)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config.setLevelRules(rules)
+	return nil
+}
@@ -0,0 +1,50 @@
+package aurora
+
+import (
+	"io"
+	"testing"
+)
+
+func TestParseLevelRules(t *testing.T) {
+	for _, spec := range []string{"db=loud", "=debug", "db=debug,http"} {
+		if _, err := ParseLevelRules(spec); err == nil {
+			t.Errorf("ParseLevelRules(%q) succeeded, want error", spec)
+		}
+	}
+
+	rules, err := ParseLevelRules("db.*=debug, http=warn ,*=error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := New(WithOutput(io.Discard), WithLevelRules(rules...))
+
+	tests := []struct {
+		name string
+		want Level
+	}{
+		{"db", DebugLevel},
+		{"db.pool", DebugLevel},
+		{"dbx", ErrorLevel},
+		{"http", WarnLevel},
+		{"http.client", ErrorLevel},
+		{"", ErrorLevel},
+	}
+	for _, tt := range tests {
+		named := log
+		if tt.name != "" {
+			named = &Logger{config: log.config, mu: log.mu, name: tt.name}
+		}
+		if got := named.level(); got != tt.want {
+			t.Errorf("level(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMustParseLevelRulesPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustParseLevelRules did not panic")
+		}
+	}()
+	MustParseLevelRules("db=loud")
+}
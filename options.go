@@ -1,6 +1,13 @@
 package aurora
 
-import "io"
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
 
 type Option func(*Config)
 
@@ -37,5 +44,118 @@ func WithCallerDepth(depth int) Option {
 func WithJSON(enabled bool) Option {
 	return func(c *Config) {
 		c.JSONOutput = enabled
+		if enabled {
+			c.Formatter = format.JSON{}
+		} else {
+			c.Formatter = nil
+		}
+	}
+}
+
+func WithFormatter(f Formatter) Option {
+	return func(c *Config) {
+		c.Formatter = f
+	}
+}
+
+func WithSinks(sinks ...Sink) Option {
+	return func(c *Config) {
+		c.Sinks = append(c.Sinks, sinks...)
+	}
+}
+
+func WithSampler(s Sampler) Option {
+	return func(c *Config) {
+		c.Sampler = s
+	}
+}
+
+func WithDedup(window time.Duration) Option {
+	return func(c *Config) {
+		c.dedup = newDeduper(window)
+	}
+}
+
+func WithContextExtractor(fn ContextExtractor) Option {
+	return func(c *Config) {
+		c.ContextExtractors = append(c.ContextExtractors, fn)
+	}
+}
+
+func WithHooks(hooks ...Hook) Option {
+	return func(c *Config) {
+		c.Hooks = append(c.Hooks, hooks...)
+	}
+}
+
+func WithRedactor(r *Redactor) Option {
+	return func(c *Config) {
+		c.Redactor = r
+	}
+}
+
+func WithNoColor(enabled bool) Option {
+	return func(c *Config) {
+		c.NoColor = enabled
+	}
+}
+
+func WithEnv() Option {
+	return func(c *Config) {
+		if v := os.Getenv("AURORA_LEVEL"); v != "" {
+			if strings.Contains(v, "=") {
+				if rules, err := ParseLevelRules(v); err == nil {
+					c.LevelRules = rules
+				}
+			} else if level, err := ParseLevel(v); err == nil {
+				c.Level = level
+			}
+		}
+		switch strings.ToLower(os.Getenv("AURORA_FORMAT")) {
+		case "json":
+			c.Formatter = format.JSON{}
+		case "logfmt":
+			c.Formatter = format.Logfmt{}
+		case "ecs":
+			c.Formatter = format.ECS()
+		case "gcp":
+			c.Formatter = format.GCP()
+		case "datadog":
+			c.Formatter = format.Datadog()
+		case "otel":
+			c.Formatter = format.OTel()
+		case "pretty", "text":
+			c.Formatter = nil
+		}
+		if v := strings.ToLower(os.Getenv("AURORA_NO_COLOR")); v != "" && v != "0" && v != "false" {
+			c.NoColor = true
+		}
+	}
+}
+
+func WithLevelRules(rules ...LevelRule) Option {
+	return func(c *Config) {
+		c.LevelRules = append([]LevelRule(nil), rules...)
+	}
+}
+
+func WithLevelConfig(level Level, cfg LevelConfig) Option {
+	return func(c *Config) {
+		if c.LevelConfigs == nil {
+			c.LevelConfigs = make(map[Level]LevelConfig)
+		}
+		c.LevelConfigs[level] = cfg
+	}
+}
+
+func WithExitFunc(fn func(code int)) Option {
+	return func(c *Config) {
+		c.ExitFunc = fn
+	}
+}
+
+func WithNoExitHandlers(enabled bool) Option {
+	return func(c *Config) {
+		c.NoExitHandlers = enabled
 	}
 }
@@ -11,11 +11,19 @@ type RGB struct {
 }
 
 func (c RGB) ANSI() string {
-	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	return string(c.appendSGR(nil, CurrentProfile(), false))
 }
 
 func (c RGB) ANSIBg() string {
-	return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", c.R, c.G, c.B)
+	return string(c.appendSGR(nil, CurrentProfile(), true))
+}
+
+func (c RGB) AppendANSI(dst []byte) []byte {
+	return c.appendSGR(dst, CurrentProfile(), false)
+}
+
+func (c RGB) AppendProfile(dst []byte, p Profile) []byte {
+	return c.appendSGR(dst, p, false)
 }
 
 func (c RGB) Hex() string {
@@ -53,18 +61,47 @@ const Italic = "\x1b[3m"
 const Underline = "\x1b[4m"
 
 func Colorize(text string, c RGB) string {
+	if !Enabled() {
+		return text
+	}
 	return c.ANSI() + text + Reset
 }
 
 func ColorizeBold(text string, c RGB) string {
+	if !Enabled() {
+		return text
+	}
 	return Bold + c.ANSI() + text + Reset
 }
 
 func ColorizeWithBg(text string, fg, bg RGB) string {
+	if !Enabled() {
+		return text
+	}
 	return fg.ANSI() + bg.ANSIBg() + text + Reset
 }
 
+func AppendColorized(dst []byte, text string, c RGB) []byte {
+	if !Enabled() {
+		return append(dst, text...)
+	}
+	dst = c.AppendANSI(dst)
+	dst = append(dst, text...)
+	return append(dst, Reset...)
+}
+
+func AppendColorizedBold(dst []byte, text string, c RGB) []byte {
+	if !Enabled() {
+		return append(dst, text...)
+	}
+	dst = append(dst, Bold...)
+	return AppendColorized(dst, text, c)
+}
+
 func ApplyStyle(text string, styles ...string) string {
+	if !Enabled() {
+		return text
+	}
 	prefix := strings.Join(styles, "")
 	return prefix + text + Reset
 }
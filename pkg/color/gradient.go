@@ -44,6 +44,9 @@ func (g Gradient) Apply(text string) string {
 	if len(text) == 0 {
 		return ""
 	}
+	if !Enabled() {
+		return text
+	}
 
 	runes := []rune(text)
 	result := ""
@@ -63,6 +66,10 @@ func (g Gradient) Apply(text string) string {
 }
 
 func (g Gradient) ApplyLines(lines []string) []string {
+	if !Enabled() {
+		return append([]string(nil), lines...)
+	}
+
 	result := make([]string, len(lines))
 
 	totalChars := 0
@@ -94,6 +101,10 @@ func (g Gradient) ApplyLines(lines []string) []string {
 }
 
 func (g Gradient) ApplyVertical(lines []string) []string {
+	if !Enabled() {
+		return append([]string(nil), lines...)
+	}
+
 	result := make([]string, len(lines))
 
 	for i, line := range lines {
@@ -111,6 +122,10 @@ func (g Gradient) ApplyVertical(lines []string) []string {
 }
 
 func (g Gradient) ApplyDiagonal(lines []string) []string {
+	if !Enabled() {
+		return append([]string(nil), lines...)
+	}
+
 	result := make([]string, len(lines))
 
 	maxLen := 0
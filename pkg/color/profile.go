@@ -0,0 +1,244 @@
+package color
+
+import (
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type Profile int32
+
+const (
+	NoColor Profile = iota
+	ANSI16
+	ANSI256
+	TrueColor
+)
+
+func (p Profile) String() string {
+	switch p {
+	case NoColor:
+		return "none"
+	case ANSI16:
+		return "16"
+	case ANSI256:
+		return "256"
+	case TrueColor:
+		return "truecolor"
+	default:
+		return "Profile(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+var (
+	profile     atomic.Int32
+	profileOnce sync.Once
+	overridden  atomic.Bool
+)
+
+func CurrentProfile() Profile {
+	profileOnce.Do(func() {
+		profile.Store(int32(DetectProfile(os.Stdout)))
+	})
+	return Profile(profile.Load())
+}
+
+func SetProfile(p Profile) {
+	profileOnce.Do(func() {})
+	profile.Store(int32(p))
+	overridden.Store(true)
+}
+
+func ProfileOverride() (Profile, bool) {
+	if !overridden.Load() {
+		return NoColor, false
+	}
+	return Profile(profile.Load()), true
+}
+
+func ProfileFor(w io.Writer) Profile {
+	if p, ok := ProfileOverride(); ok {
+		return p
+	}
+	f, _ := w.(*os.File)
+	return DetectProfile(f)
+}
+
+func Enabled() bool {
+	return CurrentProfile() != NoColor
+}
+
+func DetectProfile(f *os.File) Profile {
+	if p, ok := forcedProfile(); ok {
+		return p
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return NoColor
+	}
+	if !isTerminal(f) {
+		return NoColor
+	}
+	return envProfile()
+}
+
+func forcedProfile() (Profile, bool) {
+	v, ok := os.LookupEnv("FORCE_COLOR")
+	if !ok {
+		return NoColor, false
+	}
+
+	switch strings.ToLower(v) {
+	case "0", "false", "no", "off":
+		return NoColor, true
+	case "2":
+		return ANSI256, true
+	case "3":
+		return TrueColor, true
+	}
+	if p := envProfile(); p > ANSI16 {
+		return p, true
+	}
+	return ANSI16, true
+}
+
+func envProfile() Profile {
+	term := strings.ToLower(os.Getenv("TERM"))
+	if term == "dumb" {
+		return NoColor
+	}
+
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+
+	switch {
+	case strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"),
+		strings.Contains(term, "kitty"), strings.Contains(term, "alacritty"),
+		strings.Contains(term, "wezterm"):
+		return TrueColor
+	case strings.Contains(term, "256color"):
+		return ANSI256
+	case term != "":
+		return ANSI16
+	}
+
+	if runtime.GOOS == "windows" {
+		if os.Getenv("WT_SESSION") != "" {
+			return TrueColor
+		}
+		return ANSI16
+	}
+	return NoColor
+}
+
+func isTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+var palette16 = [16]RGB{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+func (c RGB) ANSI256() uint8 {
+	ri, gi, bi := cubeIndex(c.R), cubeIndex(c.G), cubeIndex(c.B)
+	cube := RGB{cubeLevels[ri], cubeLevels[gi], cubeLevels[bi]}
+
+	avg := (int(c.R) + int(c.G) + int(c.B)) / 3
+	grayIdx := 23
+	if avg < 238 {
+		grayIdx = (avg - 3) / 10
+		if grayIdx < 0 {
+			grayIdx = 0
+		}
+	}
+	gv := uint8(8 + 10*grayIdx)
+	gray := RGB{gv, gv, gv}
+
+	if c.distance(gray) < c.distance(cube) {
+		return uint8(232 + grayIdx)
+	}
+	return uint8(16 + 36*ri + 6*gi + bi)
+}
+
+func (c RGB) ANSI16() uint8 {
+	best, bestDist := 0, -1
+	for i, p := range palette16 {
+		if d := c.distance(p); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return uint8(best)
+}
+
+func cubeIndex(v uint8) int {
+	if v < 48 {
+		return 0
+	}
+	if v < 115 {
+		return 1
+	}
+	return int(v-35) / 40
+}
+
+func (c RGB) distance(o RGB) int {
+	dr := int(c.R) - int(o.R)
+	dg := int(c.G) - int(o.G)
+	db := int(c.B) - int(o.B)
+	return 2*dr*dr + 4*dg*dg + 3*db*db
+}
+
+func (c RGB) appendSGR(dst []byte, p Profile, bg bool) []byte {
+	switch p {
+	case NoColor:
+		return dst
+	case ANSI16:
+		idx := int(c.ANSI16())
+		code := 30 + idx
+		if idx >= 8 {
+			code = 90 + idx - 8
+		}
+		if bg {
+			code += 10
+		}
+		dst = append(dst, "\x1b["...)
+		dst = strconv.AppendInt(dst, int64(code), 10)
+		return append(dst, 'm')
+	case ANSI256:
+		if bg {
+			dst = append(dst, "\x1b[48;5;"...)
+		} else {
+			dst = append(dst, "\x1b[38;5;"...)
+		}
+		dst = strconv.AppendUint(dst, uint64(c.ANSI256()), 10)
+		return append(dst, 'm')
+	}
+
+	if bg {
+		dst = append(dst, "\x1b[48;2;"...)
+	} else {
+		dst = append(dst, "\x1b[38;2;"...)
+	}
+	dst = strconv.AppendUint(dst, uint64(c.R), 10)
+	dst = append(dst, ';')
+	dst = strconv.AppendUint(dst, uint64(c.G), 10)
+	dst = append(dst, ';')
+	dst = strconv.AppendUint(dst, uint64(c.B), 10)
+	return append(dst, 'm')
+}
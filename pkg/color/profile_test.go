@@ -0,0 +1,77 @@
+package color
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func unsetenv(t *testing.T, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+}
+
+func TestProfileFor(t *testing.T) {
+	unsetenv(t, "FORCE_COLOR", "NO_COLOR", "COLORTERM")
+	t.Setenv("TERM", "xterm-256color")
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if got := ProfileFor(f); got != NoColor {
+		t.Errorf("ProfileFor(regular file) = %v, want none", got)
+	}
+	if got := ProfileFor(&bytes.Buffer{}); got != NoColor {
+		t.Errorf("ProfileFor(buffer) = %v, want none", got)
+	}
+
+	t.Setenv("FORCE_COLOR", "3")
+	if got := ProfileFor(f); got != TrueColor {
+		t.Errorf("ProfileFor with FORCE_COLOR=3 = %v, want truecolor", got)
+	}
+	t.Setenv("FORCE_COLOR", "0")
+	if got := ProfileFor(f); got != NoColor {
+		t.Errorf("ProfileFor with FORCE_COLOR=0 = %v, want none", got)
+	}
+}
+
+func TestSetProfileOverrides(t *testing.T) {
+	unsetenv(t, "FORCE_COLOR")
+	t.Cleanup(func() { overridden.Store(false) })
+
+	if _, ok := ProfileOverride(); ok {
+		t.Fatal("override set before SetProfile")
+	}
+	SetProfile(ANSI16)
+	if p, ok := ProfileOverride(); !ok || p != ANSI16 {
+		t.Fatalf("ProfileOverride() = %v, %v; want 16, true", p, ok)
+	}
+	if got := ProfileFor(&bytes.Buffer{}); got != ANSI16 {
+		t.Fatalf("ProfileFor(buffer) = %v, want the override", got)
+	}
+}
+
+func TestAppendProfile(t *testing.T) {
+	c := RGB{R: 255, G: 0, B: 0}
+	tests := []struct {
+		p    Profile
+		want string
+	}{
+		{NoColor, ""},
+		{ANSI16, "\x1b[91m"},
+		{ANSI256, "\x1b[38;5;196m"},
+		{TrueColor, "\x1b[38;2;255;0;0m"},
+	}
+	for _, tt := range tests {
+		if got := string(c.AppendProfile(nil, tt.p)); got != tt.want {
+			t.Errorf("AppendProfile(%v) = %q, want %q", tt.p, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package format
+
+import "github.com/Summaw/aurora/pkg/color"
+
+const clfTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+type CommonLog struct {
+	Combined bool
+	Fallback Formatter
+}
+
+type accessFields struct {
+	method, path, query, proto Field
+	status, bytes              Field
+	ip, user                   Field
+	userAgent, referer         Field
+}
+
+func (c CommonLog) WithProfile(profile color.Profile) Formatter {
+	fallback := c.Fallback
+	if fallback == nil {
+		fallback = Pretty{}
+	}
+	if cf, ok := fallback.(ColorFormatter); ok {
+		c.Fallback = cf.WithProfile(profile)
+	}
+	return c
+}
+
+func (c CommonLog) Format(dst []byte, r *Record) []byte {
+	var a accessFields
+	for _, f := range r.Fields {
+		switch f.Key {
+		case "method":
+			a.method = f
+		case "path":
+			a.path = f
+		case "query":
+			a.query = f
+		case "proto":
+			a.proto = f
+		case "status":
+			a.status = f
+		case "bytes":
+			a.bytes = f
+		case "ip":
+			a.ip = f
+		case "user":
+			a.user = f
+		case "user_agent":
+			a.userAgent = f
+		case "referer":
+			a.referer = f
+		}
+	}
+
+	if a.method.Key == "" || a.path.Key == "" || a.status.Key == "" {
+		if c.Fallback != nil {
+			return c.Fallback.Format(dst, r)
+		}
+		return Pretty{}.Format(dst, r)
+	}
+
+	dst = appendCLFField(dst, a.ip)
+	dst = append(dst, " - "...)
+	dst = appendCLFField(dst, a.user)
+	dst = append(dst, " ["...)
+	dst = r.Time.AppendFormat(dst, clfTimeFormat)
+	dst = append(dst, `] "`...)
+
+	dst = appendCLFEscaped(dst, a.method.AppendText(nil))
+	dst = append(dst, ' ')
+	dst = appendCLFEscaped(dst, a.path.AppendText(nil))
+	if a.query.Key != "" {
+		dst = append(dst, '?')
+		dst = appendCLFEscaped(dst, a.query.AppendText(nil))
+	}
+	dst = append(dst, ' ')
+	if a.proto.Key != "" {
+		dst = appendCLFEscaped(dst, a.proto.AppendText(nil))
+	} else {
+		dst = append(dst, "HTTP/1.1"...)
+	}
+	dst = append(dst, `" `...)
+
+	dst = a.status.AppendText(dst)
+	dst = append(dst, ' ')
+	if a.bytes.Key == "" || (a.bytes.Kind == KindInt64 && a.bytes.num == 0) {
+		dst = append(dst, '-')
+	} else {
+		dst = a.bytes.AppendText(dst)
+	}
+
+	if c.Combined {
+		dst = append(dst, ' ')
+		dst = appendCLFQuoted(dst, a.referer)
+		dst = append(dst, ' ')
+		dst = appendCLFQuoted(dst, a.userAgent)
+	}
+
+	dst = append(dst, '\n')
+	return dst
+}
+
+func appendCLFField(dst []byte, f Field) []byte {
+	if f.Key == "" {
+		return append(dst, '-')
+	}
+	text := f.AppendText(nil)
+	if len(text) == 0 {
+		return append(dst, '-')
+	}
+	return appendCLFEscaped(dst, text)
+}
+
+func appendCLFQuoted(dst []byte, f Field) []byte {
+	dst = append(dst, '"')
+	dst = appendCLFField(dst, f)
+	return append(dst, '"')
+}
+
+func appendCLFEscaped(dst, s []byte) []byte {
+	const hex = "0123456789abcdef"
+	for _, c := range s {
+		switch {
+		case c == '"' || c == '\\':
+			dst = append(dst, '\\', c)
+		case c < 0x20 || c == 0x7f:
+			dst = append(dst, '\\', 'x', hex[c>>4], hex[c&0xf])
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,66 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Summaw/aurora/pkg/color"
+)
+
+func accessRecord(fields ...Field) *Record {
+	return &Record{
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("", -7*3600)),
+		Level:   Level{Value: 20, Name: "INFO"},
+		Message: "HTTP Request",
+		Fields:  fields,
+	}
+}
+
+func TestCommonLog(t *testing.T) {
+	base := []Field{String("method", "GET"), String("path", "/users"), Int64("status", 200)}
+	tests := []struct {
+		name   string
+		f      CommonLog
+		fields []Field
+		want   string
+	}{
+		{"Minimal", CommonLog{}, base,
+			`- - - [02/Jan/2024:03:04:05 -0700] "GET /users HTTP/1.1" 200 -`},
+		{"Full", CommonLog{}, append(base[:3:3],
+			String("query", "page=2"), String("proto", "HTTP/2.0"), Int64("bytes", 512),
+			String("ip", "10.0.0.1"), String("user", "bob")),
+			`10.0.0.1 - bob [02/Jan/2024:03:04:05 -0700] "GET /users?page=2 HTTP/2.0" 200 512`},
+		{"ZeroBytes", CommonLog{}, append(base[:3:3], Int64("bytes", 0)),
+			`- - - [02/Jan/2024:03:04:05 -0700] "GET /users HTTP/1.1" 200 -`},
+		{"Escaping", CommonLog{}, []Field{
+			String("method", "GET"), String("path", "/a\"b\\c\nd"), Int64("status", 404), String("user", "")},
+			`- - - [02/Jan/2024:03:04:05 -0700] "GET /a\"b\\c\x0ad HTTP/1.1" 404 -`},
+		{"Combined", CommonLog{Combined: true}, append(base[:3:3],
+			String("referer", "https://example.com/?q=\"x\""), String("user_agent", "curl/8")),
+			`- - - [02/Jan/2024:03:04:05 -0700] "GET /users HTTP/1.1" 200 - "https://example.com/?q=\"x\"" "curl/8"`},
+		{"CombinedMissing", CommonLog{Combined: true}, base,
+			`- - - [02/Jan/2024:03:04:05 -0700] "GET /users HTTP/1.1" 200 - "-" "-"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(tt.f.Format(nil, accessRecord(tt.fields...)))
+			if got != tt.want+"\n" {
+				t.Fatalf("got  %q\nwant %q", got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestCommonLogFallback(t *testing.T) {
+	r := accessRecord(String("method", "GET"), Int64("status", 200))
+
+	got := string(CommonLog{Fallback: Logfmt{}}.Format(nil, r))
+	if want := string(Logfmt{}.Format(nil, r)); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	got = string(CommonLog{}.WithProfile(color.NoColor).Format(nil, r))
+	if want := string(Pretty{}.WithProfile(color.NoColor).Format(nil, r)); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
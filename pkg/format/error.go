@@ -0,0 +1,151 @@
+package format
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+)
+
+type StackTrace []uintptr
+
+func CaptureStack(skip int) StackTrace {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+2, pcs)
+	return StackTrace(pcs[:n])
+}
+
+func (s StackTrace) Frames() []runtime.Frame {
+	if len(s) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(s)
+	result := make([]runtime.Frame, 0, len(s))
+	for {
+		frame, more := frames.Next()
+		result = append(result, frame)
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+func Error(key string, err error) Field {
+	return Field{Key: key, Kind: KindError, value: err}
+}
+
+func Stack(key string, stack StackTrace) Field {
+	return Field{Key: key, value: stack}
+}
+
+func UnwrapAll(err error) []error {
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		return x.Unwrap()
+	case interface{ Unwrap() error }:
+		if cause := x.Unwrap(); cause != nil {
+			return []error{cause}
+		}
+	}
+	return nil
+}
+
+// maxCauses bounds how far an error tree is walked, so that an error whose
+// Unwrap returns itself or an ancestor cannot loop forever.
+const maxCauses = 64
+
+func Causes(err error) []error {
+	return appendCauses(nil, err)
+}
+
+func appendCauses(causes []error, err error) []error {
+	for _, cause := range UnwrapAll(err) {
+		if len(causes) >= maxCauses {
+			break
+		}
+		causes = append(causes, cause)
+		causes = appendCauses(causes, cause)
+	}
+	return causes
+}
+
+func ErrorStack(err error) StackTrace {
+	budget := maxCauses
+	return errorStack(err, &budget)
+}
+
+func errorStack(err error, budget *int) StackTrace {
+	if err == nil || *budget <= 0 {
+		return nil
+	}
+	*budget--
+	for _, cause := range UnwrapAll(err) {
+		if stack := errorStack(cause, budget); len(stack) > 0 {
+			return stack
+		}
+	}
+	return stackOf(err)
+}
+
+var uintptrType = reflect.TypeOf(uintptr(0))
+
+func stackOf(err error) StackTrace {
+	v := reflect.ValueOf(err)
+	for _, name := range []string{"StackTrace", "Callers"} {
+		m := v.MethodByName(name)
+		if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+			continue
+		}
+		out := m.Type().Out(0)
+		if out.Kind() != reflect.Slice || !out.Elem().ConvertibleTo(uintptrType) || out.Elem().Kind() != reflect.Uintptr {
+			continue
+		}
+		res := m.Call(nil)[0]
+		stack := make(StackTrace, res.Len())
+		for i := range stack {
+			stack[i] = uintptr(res.Index(i).Uint())
+		}
+		return stack
+	}
+	return nil
+}
+
+func (f Field) errorChildren() []Field {
+	err, _ := f.value.(error)
+	if err == nil {
+		return nil
+	}
+
+	var children []Field
+	for _, cause := range UnwrapAll(err) {
+		children = append(children, Field{Key: "caused by", Kind: KindError, value: cause, num: 1})
+	}
+	if f.num == 0 {
+		if stack := ErrorStack(err); len(stack) > 0 {
+			children = append(children, Stack("stack", stack))
+		}
+	}
+	return children
+}
+
+func (s StackTrace) fields() []Field {
+	frames := s.Frames()
+	fields := make([]Field, len(frames))
+	for i, frame := range frames {
+		fields[i] = String(frame.Function, string(appendFrameLocation(nil, frame)))
+	}
+	return fields
+}
+
+func appendFrame(dst []byte, frame runtime.Frame) []byte {
+	dst = append(dst, frame.Function...)
+	dst = append(dst, ' ')
+	return appendFrameLocation(dst, frame)
+}
+
+func appendFrameLocation(dst []byte, frame runtime.Frame) []byte {
+	dst = append(dst, filepath.Base(frame.File)...)
+	dst = append(dst, ':')
+	return strconv.AppendInt(dst, int64(frame.Line), 10)
+}
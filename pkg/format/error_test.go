@@ -0,0 +1,133 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+)
+
+func errorRecord(fields ...Field) *Record {
+	return &Record{
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   Level{Value: 40, Name: "ERROR"},
+		Message: "failed",
+		Fields:  fields,
+	}
+}
+
+func TestPrettyErrorTree(t *testing.T) {
+	err := fmt.Errorf("load: %w", errors.Join(errors.New("a"), fmt.Errorf("b: %w", fs.ErrNotExist)))
+	out := string(Pretty{NoColor: true}.Format(nil, errorRecord(Error("error", err), String("note", "x\ny"))))
+
+	want := strings.Join([]string{
+		"",
+		"  03:04:05.000   ERROR  failed",
+		"            ├─ error: load: a",
+		"            │  b: file does not exist",
+		"            │  └─ caused by: a",
+		"            │     b: file does not exist",
+		"            │     ├─ caused by: a",
+		"            │     └─ caused by: b: file does not exist",
+		"            │        └─ caused by: file does not exist",
+		"            └─ note: x",
+		"               y",
+		"",
+	}, "\n")
+	if out != want {
+		t.Fatalf("got\n%s\nwant\n%s", out, want)
+	}
+}
+
+type loopError struct{}
+
+func (loopError) Error() string { return "loop" }
+func (e loopError) Unwrap() error {
+	return e
+}
+
+type joinLoopError struct{}
+
+func (joinLoopError) Error() string { return "join loop" }
+func (e joinLoopError) Unwrap() []error {
+	return []error{e, e}
+}
+
+func TestCausesCycle(t *testing.T) {
+	for _, err := range []error{loopError{}, joinLoopError{}} {
+		if got := len(Causes(err)); got != maxCauses {
+			t.Errorf("%v: %d causes, want %d", err, got, maxCauses)
+		}
+		if stack := ErrorStack(err); stack != nil {
+			t.Errorf("%v: unexpected stack %v", err, stack)
+		}
+		Pretty{NoColor: true}.Format(nil, errorRecord(Error("error", err)))
+		Logfmt{}.Format(nil, errorRecord(Error("error", err)))
+		if err := checkJSON(JSON{}.Format(nil, errorRecord(Error("error", err)))); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+type frame uintptr
+
+type pkgError struct{ stack []frame }
+
+func (e pkgError) Error() string       { return "pkg error" }
+func (e pkgError) StackTrace() []frame { return e.stack }
+
+type callersError struct{ pcs []uintptr }
+
+func (e callersError) Error() string      { return "callers error" }
+func (e callersError) Callers() []uintptr { return e.pcs }
+
+type badStackError struct{}
+
+func (badStackError) Error() string        { return "bad stack" }
+func (badStackError) StackTrace() []string { return []string{"x"} }
+
+func TestErrorStackDetection(t *testing.T) {
+	pcs := CaptureStack(0)
+	frames := make([]frame, len(pcs))
+	for i, pc := range pcs {
+		frames[i] = frame(pc)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"StackTrace", pkgError{frames}, true},
+		{"Callers", callersError{pcs}, true},
+		{"Wrapped", fmt.Errorf("wrap: %w", callersError{pcs}), true},
+		{"Joined", errors.Join(errors.New("a"), callersError{pcs}), true},
+		{"WrongType", badStackError{}, false},
+		{"Plain", errors.New("plain"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack := ErrorStack(tt.err)
+			if got := len(stack) > 0; got != tt.want {
+				t.Fatalf("found stack = %v, want %v", got, tt.want)
+			}
+			out := string(Pretty{NoColor: true}.Format(nil, errorRecord(Error("error", tt.err))))
+			if got := strings.Contains(out, "TestErrorStackDetection"); got != tt.want {
+				t.Fatalf("stack printed = %v, want %v:\n%s", got, tt.want, out)
+			}
+		})
+	}
+}
+
+func TestPrettyStack(t *testing.T) {
+	out := string(Pretty{NoColor: true}.Format(nil, errorRecord(Stack("stack", CaptureStack(0)))))
+	lines := strings.Split(out, "\n")
+	if len(lines) < 4 || lines[2] != "            └─ stack:" {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+	if !strings.Contains(lines[3], "format.TestPrettyStack: error_test.go:") {
+		t.Fatalf("first frame = %q", lines[3])
+	}
+}
@@ -0,0 +1,151 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+type Kind uint8
+
+const (
+	KindAny Kind = iota
+	KindString
+	KindInt64
+	KindUint64
+	KindFloat32
+	KindFloat64
+	KindBool
+	KindDuration
+	KindTime
+	KindError
+)
+
+type Field struct {
+	Key   string
+	Kind  Kind
+	value any
+	num   uint64
+	str   string
+}
+
+func String(key, value string) Field {
+	return Field{Key: key, Kind: KindString, str: value}
+}
+
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Kind: KindInt64, num: uint64(value)}
+}
+
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Kind: KindUint64, num: value}
+}
+
+func Float32(key string, value float32) Field {
+	return Field{Key: key, Kind: KindFloat32, num: uint64(math.Float32bits(value))}
+}
+
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Kind: KindFloat64, num: math.Float64bits(value)}
+}
+
+func Bool(key string, value bool) Field {
+	f := Field{Key: key, Kind: KindBool}
+	if value {
+		f.num = 1
+	}
+	return f
+}
+
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Kind: KindDuration, num: uint64(value)}
+}
+
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Kind: KindTime, num: uint64(value.UnixNano()), value: value.Location()}
+}
+
+func Any(key string, value any) Field {
+	return Field{Key: key, value: value}
+}
+
+func Group(key string, fields ...Field) Field {
+	return Field{Key: key, value: fields}
+}
+
+func (f Field) Any() any {
+	switch f.Kind {
+	case KindString:
+		return f.str
+	case KindInt64:
+		return int64(f.num)
+	case KindUint64:
+		return f.num
+	case KindFloat32:
+		return math.Float32frombits(uint32(f.num))
+	case KindFloat64:
+		return math.Float64frombits(f.num)
+	case KindBool:
+		return f.num == 1
+	case KindDuration:
+		return time.Duration(f.num)
+	case KindTime:
+		return f.time()
+	case KindError:
+		return f.value
+	default:
+		return f.value
+	}
+}
+
+func (f Field) time() time.Time {
+	t := time.Unix(0, int64(f.num))
+	if loc, ok := f.value.(*time.Location); ok && loc != nil {
+		return t.In(loc)
+	}
+	return t.UTC()
+}
+
+func (f Field) AppendText(dst []byte) []byte {
+	switch f.Kind {
+	case KindString:
+		return append(dst, f.str...)
+	case KindInt64:
+		return strconv.AppendInt(dst, int64(f.num), 10)
+	case KindUint64:
+		return strconv.AppendUint(dst, f.num, 10)
+	case KindFloat32:
+		return strconv.AppendFloat(dst, float64(math.Float32frombits(uint32(f.num))), 'g', -1, 32)
+	case KindFloat64:
+		return strconv.AppendFloat(dst, math.Float64frombits(f.num), 'g', -1, 64)
+	case KindBool:
+		return strconv.AppendBool(dst, f.num == 1)
+	case KindDuration:
+		return AppendDuration(dst, time.Duration(f.num))
+	case KindTime:
+		return f.time().AppendFormat(dst, time.RFC3339)
+	case KindError:
+		if err, ok := f.value.(error); ok && err != nil {
+			return append(dst, err.Error()...)
+		}
+		return append(dst, "<nil>"...)
+	default:
+		return fmt.Append(dst, f.value)
+	}
+}
+
+func AppendDuration(dst []byte, d time.Duration) []byte {
+	switch {
+	case d < time.Microsecond:
+		return append(strconv.AppendInt(dst, d.Nanoseconds(), 10), "ns"...)
+	case d < time.Millisecond:
+		return append(strconv.AppendFloat(dst, float64(d.Nanoseconds())/1000, 'f', 2, 64), "µs"...)
+	case d < time.Second:
+		return append(strconv.AppendFloat(dst, float64(d.Nanoseconds())/1e6, 'f', 2, 64), "ms"...)
+	case d < time.Minute:
+		return append(strconv.AppendFloat(dst, d.Seconds(), 'f', 2, 64), 's')
+	default:
+		return append(dst, d.String()...)
+	}
+}
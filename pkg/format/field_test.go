@@ -0,0 +1,42 @@
+package format
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFieldAny(t *testing.T) {
+	loc := time.FixedZone("CET", 3600)
+	when := time.Date(2024, 1, 2, 3, 4, 5, 6, loc)
+	err := errors.New("boom")
+	group := []Field{String("a", "b")}
+
+	tests := []struct {
+		field Field
+		want  any
+	}{
+		{String("k", "v"), "v"},
+		{Int64("k", -3), int64(-3)},
+		{Uint64("k", 3), uint64(3)},
+		{Float32("k", 1.5), float32(1.5)},
+		{Float64("k", 2.5), 2.5},
+		{Bool("k", true), true},
+		{Bool("k", false), false},
+		{Duration("k", time.Second), time.Second},
+		{Error("k", err), err},
+		{Any("k", map[string]int{"x": 1}), map[string]int{"x": 1}},
+		{Group("k", group...), group},
+	}
+	for _, tt := range tests {
+		if got := tt.field.Any(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Kind %d: Any() = %#v, want %#v", tt.field.Kind, got, tt.want)
+		}
+	}
+
+	got, ok := Time("k", when).Any().(time.Time)
+	if !ok || !got.Equal(when) || got.Location() != loc {
+		t.Errorf("Time Any() = %v, want %v", got, when)
+	}
+}
@@ -0,0 +1,39 @@
+package format
+
+import (
+	"time"
+
+	"github.com/Summaw/aurora/pkg/color"
+)
+
+type Level struct {
+	Value int
+	Name  string
+	Icon  string
+	Color color.RGB
+	Bold  bool
+}
+
+type Record struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  []Field
+	Caller  string
+	Name    string
+}
+
+type Formatter interface {
+	Format(dst []byte, r *Record) []byte
+}
+
+type ColorFormatter interface {
+	Formatter
+	WithProfile(p color.Profile) Formatter
+}
+
+type FormatterFunc func(dst []byte, r *Record) []byte
+
+func (f FormatterFunc) Format(dst []byte, r *Record) []byte {
+	return f(dst, r)
+}
@@ -0,0 +1,455 @@
+package format
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	TimeUnixNano       = "unixnano"
+	TimeUnixNanoString = "unixnano-string"
+)
+
+type JSON struct {
+	TimeKey           string
+	TimeFormat        string
+	LevelKey          string
+	LevelValue        func(Level) string
+	SeverityNumberKey string
+	MessageKey        string
+	NameKey           string
+	CallerKey         string
+	CallerFileKey     string
+	CallerLineKey     string
+	FieldsKey         string
+	Static            []Field
+}
+
+func (j JSON) Format(dst []byte, r *Record) []byte {
+	dst = append(dst, '{')
+	first := true
+
+	if key := jsonKey(j.TimeKey, "timestamp"); key != "" && !r.Time.IsZero() {
+		dst, first = appendJSONKey(dst, key, first)
+		dst = j.appendTime(dst, r)
+	}
+
+	if key := jsonKey(j.LevelKey, "level"); key != "" {
+		dst, first = appendJSONKey(dst, key, first)
+		if j.LevelValue != nil {
+			dst = appendJSONString(dst, j.LevelValue(r.Level))
+		} else {
+			dst = appendJSONString(dst, r.Level.Name)
+		}
+	}
+
+	if j.SeverityNumberKey != "" {
+		dst, first = appendJSONKey(dst, j.SeverityNumberKey, first)
+		dst = strconv.AppendInt(dst, int64(OTelSeverityNumber(r.Level)), 10)
+	}
+
+	if j.FieldsKey == "" {
+		dst, first = j.appendName(dst, r, first)
+	}
+
+	if key := jsonKey(j.MessageKey, "message"); key != "" {
+		dst, first = appendJSONKey(dst, key, first)
+		dst = appendJSONString(dst, r.Message)
+	}
+
+	keys := j.fieldKeys(false)
+	for _, field := range j.Static {
+		dst, first = appendJSONSeparator(dst, first)
+		dst = keys.appendJSONField(dst, field)
+	}
+
+	if j.FieldsKey == "" {
+		for _, field := range r.Fields {
+			dst, first = appendJSONSeparator(dst, first)
+			dst = keys.appendJSONField(dst, field)
+		}
+		dst, _ = j.appendCaller(dst, r, first)
+		return append(dst, "}\n"...)
+	}
+
+	if len(r.Fields) > 0 || r.Name != "" || r.Caller != "" {
+		dst, _ = appendJSONKey(dst, j.FieldsKey, first)
+		dst = append(dst, '{')
+		inner := true
+		dst, inner = j.appendName(dst, r, inner)
+		keys := j.fieldKeys(true)
+		for _, field := range r.Fields {
+			dst, inner = appendJSONSeparator(dst, inner)
+			dst = keys.appendJSONField(dst, field)
+		}
+		dst, _ = j.appendCaller(dst, r, inner)
+		dst = append(dst, '}')
+	}
+
+	return append(dst, "}\n"...)
+}
+
+func (j *JSON) appendTime(dst []byte, r *Record) []byte {
+	switch j.TimeFormat {
+	case "":
+		dst = append(dst, '"')
+		dst = r.Time.AppendFormat(dst, time.RFC3339Nano)
+		return append(dst, '"')
+	case TimeUnixNano:
+		return strconv.AppendInt(dst, r.Time.UnixNano(), 10)
+	case TimeUnixNanoString:
+		dst = append(dst, '"')
+		dst = strconv.AppendInt(dst, r.Time.UnixNano(), 10)
+		return append(dst, '"')
+	}
+	dst = append(dst, '"')
+	start := len(dst)
+	dst = r.Time.AppendFormat(dst, j.TimeFormat)
+	dst = escapeJSONTail(dst, start)
+	return append(dst, '"')
+}
+
+func (j *JSON) appendName(dst []byte, r *Record, first bool) ([]byte, bool) {
+	if r.Name == "" {
+		return dst, first
+	}
+	if key := jsonKey(j.NameKey, "logger"); key != "" {
+		dst, first = appendJSONKey(dst, key, first)
+		dst = appendJSONString(dst, r.Name)
+	}
+	return dst, first
+}
+
+func (j *JSON) appendCaller(dst []byte, r *Record, first bool) ([]byte, bool) {
+	if r.Caller == "" {
+		return dst, first
+	}
+
+	if j.CallerFileKey == "" || j.CallerLineKey == "" {
+		if key := jsonKey(j.CallerKey, "caller"); key != "" {
+			dst, first = appendJSONKey(dst, key, first)
+			dst = appendJSONString(dst, r.Caller)
+		}
+		return dst, first
+	}
+
+	file, line := r.Caller, ""
+	if i := strings.LastIndexByte(r.Caller, ':'); i >= 0 {
+		file, line = r.Caller[:i], r.Caller[i+1:]
+	}
+
+	nested := j.CallerKey != "" && j.CallerKey != "-"
+	if nested {
+		dst, first = appendJSONKey(dst, j.CallerKey, first)
+		dst = append(dst, '{')
+	}
+
+	inner := first
+	if nested {
+		inner = true
+	}
+	dst, inner = appendJSONKey(dst, j.CallerFileKey, inner)
+	dst = appendJSONString(dst, file)
+	if n, err := strconv.Atoi(line); err == nil {
+		dst, inner = appendJSONKey(dst, j.CallerLineKey, inner)
+		dst = strconv.AppendInt(dst, int64(n), 10)
+	}
+
+	if nested {
+		return append(dst, '}'), first
+	}
+	return dst, inner
+}
+
+func (j *JSON) fieldKeys(nested bool) keySet {
+	var keys keySet
+	if nested || j.FieldsKey == "" {
+		keys.reserve(jsonKey(j.NameKey, "logger"))
+		if j.CallerFileKey != "" && j.CallerLineKey != "" {
+			if j.CallerKey == "" || j.CallerKey == "-" {
+				keys.reserve(j.CallerFileKey)
+				keys.reserve(j.CallerLineKey)
+			} else {
+				keys.reserve(j.CallerKey)
+			}
+		} else {
+			keys.reserve(jsonKey(j.CallerKey, "caller"))
+		}
+	}
+	if !nested {
+		keys.reserve(jsonKey(j.TimeKey, "timestamp"))
+		keys.reserve(jsonKey(j.LevelKey, "level"))
+		keys.reserve(jsonKey(j.MessageKey, "message"))
+		keys.reserve(j.SeverityNumberKey)
+		keys.reserve(j.FieldsKey)
+	}
+	return keys
+}
+
+func jsonKey(key, def string) string {
+	switch key {
+	case "":
+		return def
+	case "-":
+		return ""
+	}
+	return key
+}
+
+func appendJSONSeparator(dst []byte, first bool) ([]byte, bool) {
+	if !first {
+		dst = append(dst, ',')
+	}
+	return dst, false
+}
+
+func appendJSONKey(dst []byte, key string, first bool) ([]byte, bool) {
+	dst, first = appendJSONSeparator(dst, first)
+	dst = appendJSONString(dst, key)
+	return append(dst, ':'), first
+}
+
+func (k *keySet) appendJSONFieldKey(dst []byte, key, suffix string, prefixes int) ([]byte, int) {
+	dst = append(dst, '"')
+	start := len(dst)
+	for ; ; prefixes++ {
+		dst = dst[:start]
+		for i := 0; i < prefixes; i++ {
+			dst = append(dst, fieldPrefix...)
+		}
+		dst = appendJSONStringContents(dst, key)
+		dst = appendJSONStringContents(dst, suffix)
+		if !k.taken(dst, start) {
+			break
+		}
+	}
+	k.add(start, len(dst))
+	return append(dst, `":`...), prefixes
+}
+
+func (k *keySet) appendJSONField(dst []byte, field Field) []byte {
+	dst, prefixes := k.appendJSONFieldKey(dst, field.Key, "", 0)
+	dst = appendJSONValue(dst, field)
+
+	if field.Kind == KindError {
+		err, _ := field.value.(error)
+		if causes := Causes(err); len(causes) > 0 {
+			dst = append(dst, ',')
+			dst, _ = k.appendJSONFieldKey(dst, field.Key, "_causes", prefixes)
+			dst = append(dst, '[')
+			for i, cause := range causes {
+				if i > 0 {
+					dst = append(dst, ',')
+				}
+				dst = appendJSONString(dst, cause.Error())
+			}
+			dst = append(dst, ']')
+		}
+		if stack := ErrorStack(err); len(stack) > 0 {
+			dst = append(dst, ',')
+			dst, _ = k.appendJSONFieldKey(dst, field.Key, "_stack", prefixes)
+			dst = appendJSONStack(dst, stack)
+		}
+	}
+	return dst
+}
+
+func appendJSONStack(dst []byte, stack StackTrace) []byte {
+	dst = append(dst, '[')
+	for i, frame := range stack.Frames() {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = append(dst, '"')
+		start := len(dst)
+		dst = appendFrame(dst, frame)
+		dst = escapeJSONTail(dst, start)
+		dst = append(dst, '"')
+	}
+	return append(dst, ']')
+}
+
+func appendJSONValue(dst []byte, field Field) []byte {
+	switch field.Kind {
+	case KindString:
+		return appendJSONString(dst, field.str)
+	case KindInt64, KindUint64, KindBool:
+		return field.AppendText(dst)
+	case KindFloat32:
+		return appendJSONFloat(dst, float64(math.Float32frombits(uint32(field.num))), 32)
+	case KindFloat64:
+		return appendJSONFloat(dst, math.Float64frombits(field.num), 64)
+	case KindDuration:
+		dst = append(dst, '"')
+		dst = field.AppendText(dst)
+		return append(dst, '"')
+	case KindError, KindTime:
+		dst = append(dst, '"')
+		start := len(dst)
+		dst = field.AppendText(dst)
+		dst = escapeJSONTail(dst, start)
+		return append(dst, '"')
+	}
+
+	switch val := field.value.(type) {
+	case string:
+		return appendJSONString(dst, val)
+	case int:
+		return strconv.AppendInt(dst, int64(val), 10)
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Appendf(dst, "%d", val)
+	case float32:
+		return appendJSONFloat(dst, float64(val), 32)
+	case float64:
+		return appendJSONFloat(dst, val, 64)
+	case bool:
+		return strconv.AppendBool(dst, val)
+	case StackTrace:
+		return appendJSONStack(dst, val)
+	case rendered:
+		return append(dst, val.json...)
+	case []Field:
+		var keys keySet
+		dst = append(dst, '{')
+		for i, f := range val {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = keys.appendJSONField(dst, f)
+		}
+		return append(dst, '}')
+	case json.Marshaler, encoding.TextMarshaler:
+		return appendJSONMarshal(dst, val)
+	case error:
+		return appendJSONString(dst, val.Error())
+	case fmt.Stringer:
+		return appendJSONString(dst, val.String())
+	default:
+		return appendJSONMarshal(dst, val)
+	}
+}
+
+func appendJSONFloat(dst []byte, f float64, bits int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(dst, `"NaN"`...)
+	case math.IsInf(f, 1):
+		return append(dst, `"+Inf"`...)
+	case math.IsInf(f, -1):
+		return append(dst, `"-Inf"`...)
+	}
+	return strconv.AppendFloat(dst, f, 'g', -1, bits)
+}
+
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	dst = appendJSONStringContents(dst, s)
+	return append(dst, '"')
+}
+
+var jsonSafe = func() (safe [256]bool) {
+	for c := 0x20; c < utf8.RuneSelf; c++ {
+		safe[c] = c != '"' && c != '\\'
+	}
+	return safe
+}()
+
+func appendJSONStringContents(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+
+	i := jsonSafePrefix(s)
+	if i == len(s) {
+		return append(dst, s...)
+	}
+
+	start := 0
+	for i < len(s) {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if jsonSafe[c] {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, "\uFFFD"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\u202`...)
+			dst = append(dst, hex[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	return append(dst, s[start:]...)
+}
+
+func jsonSafePrefix(s string) int {
+	i := 0
+	for i < len(s) && jsonSafe[s[i]] {
+		i++
+	}
+	return i
+}
+
+func escapeJSONTail(dst []byte, start int) []byte {
+	if !jsonNeedsEscape(dst[start:]) {
+		return dst
+	}
+	raw := string(dst[start:])
+	return appendJSONStringContents(dst[:start], raw)
+}
+
+func jsonNeedsEscape(b []byte) bool {
+	for i := 0; i < len(b); {
+		c := b[i]
+		if c < utf8.RuneSelf {
+			if !jsonSafe[c] {
+				return true
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(b[i:])
+		if (r == utf8.RuneError && size == 1) || r == '\u2028' || r == '\u2029' {
+			return true
+		}
+		i += size
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package format
+
+import "strings"
+
+const ECSVersion = "8.11.0"
+
+func ECS() JSON {
+	return JSON{
+		TimeKey:       "@timestamp",
+		LevelKey:      "log.level",
+		LevelValue:    LowerLevel,
+		MessageKey:    "message",
+		NameKey:       "log.logger",
+		CallerKey:     "-",
+		CallerFileKey: "log.origin.file.name",
+		CallerLineKey: "log.origin.file.line",
+		Static:        []Field{String("ecs.version", ECSVersion)},
+	}
+}
+
+func GCP() JSON {
+	return JSON{
+		TimeKey:       "timestamp",
+		LevelKey:      "severity",
+		LevelValue:    GCPSeverity,
+		MessageKey:    "message",
+		NameKey:       "logger",
+		CallerKey:     "logging.googleapis.com/sourceLocation",
+		CallerFileKey: "file",
+		CallerLineKey: "line",
+	}
+}
+
+func Datadog() JSON {
+	return JSON{
+		TimeKey:    "timestamp",
+		LevelKey:   "status",
+		LevelValue: DatadogStatus,
+		MessageKey: "message",
+		NameKey:    "logger.name",
+		CallerKey:  "caller",
+	}
+}
+
+func OTel() JSON {
+	return JSON{
+		TimeKey:           "Timestamp",
+		TimeFormat:        TimeUnixNanoString,
+		LevelKey:          "SeverityText",
+		SeverityNumberKey: "SeverityNumber",
+		MessageKey:        "Body",
+		NameKey:           "logger.name",
+		CallerKey:         "-",
+		CallerFileKey:     "code.filepath",
+		CallerLineKey:     "code.lineno",
+		FieldsKey:         "Attributes",
+	}
+}
+
+func LowerLevel(l Level) string {
+	return strings.ToLower(l.Name)
+}
+
+func GCPSeverity(l Level) string {
+	switch {
+	case l.Value < 20:
+		return "DEBUG"
+	case l.Value < 25:
+		return "INFO"
+	case l.Value < 30:
+		return "NOTICE"
+	case l.Value < 40:
+		return "WARNING"
+	case l.Value < 50:
+		return "ERROR"
+	case l.Value < 60:
+		return "CRITICAL"
+	default:
+		return "ALERT"
+	}
+}
+
+func DatadogStatus(l Level) string {
+	switch {
+	case l.Value < 10:
+		return "trace"
+	case l.Value < 20:
+		return "debug"
+	case l.Value < 25:
+		return "info"
+	case l.Value < 30:
+		return "notice"
+	case l.Value < 40:
+		return "warn"
+	case l.Value < 50:
+		return "error"
+	case l.Value < 60:
+		return "critical"
+	default:
+		return "emergency"
+	}
+}
+
+func OTelSeverityNumber(l Level) int {
+	n := 1 + l.Value*4/10
+	if n < 1 {
+		return 1
+	}
+	if n > 24 {
+		return 24
+	}
+	return n
+}
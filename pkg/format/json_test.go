@@ -0,0 +1,208 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+	"time"
+)
+
+func checkJSON(line []byte) error {
+	if !json.Valid(line) {
+		return fmt.Errorf("invalid JSON: %q", line)
+	}
+
+	type frame struct {
+		object    bool
+		expectKey bool
+		keys      map[string]bool
+	}
+	var stack []*frame
+
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%v: %q", err, line)
+		}
+
+		if n := len(stack); n > 0 && stack[n-1].object && stack[n-1].expectKey {
+			if d, ok := tok.(json.Delim); ok && d == '}' {
+				stack = stack[:n-1]
+				continue
+			}
+			key := tok.(string)
+			if stack[n-1].keys[key] {
+				return fmt.Errorf("duplicate key %q: %q", key, line)
+			}
+			stack[n-1].keys[key] = true
+			stack[n-1].expectKey = false
+			continue
+		}
+
+		if n := len(stack); n > 0 && stack[n-1].object {
+			stack[n-1].expectKey = true
+		}
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, &frame{object: true, expectKey: true, keys: map[string]bool{}})
+		case json.Delim('['):
+			stack = append(stack, &frame{})
+		case json.Delim(']'):
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+func jsonFormatters(timeFormat string, static ...Field) map[string]JSON {
+	return map[string]JSON{
+		"Default":        {},
+		"ECS":            ECS(),
+		"GCP":            GCP(),
+		"Datadog":        Datadog(),
+		"OTel":           OTel(),
+		"FieldsKey":      {FieldsKey: "fields"},
+		"TimeFormat":     {TimeFormat: timeFormat},
+		"UnixNano":       {TimeFormat: TimeUnixNano},
+		"UnixNanoString": {TimeFormat: TimeUnixNanoString},
+		"Static":         {Static: static},
+	}
+}
+
+func TestJSONDuplicateKeys(t *testing.T) {
+	wrapped := fmt.Errorf("save: %w", errors.New("disk full"))
+	r := &Record{
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   Level{Value: 20, Name: "INFO"},
+		Message: "x",
+		Name:    "api",
+		Caller:  "main.go:12",
+		Fields: []Field{
+			String("level", "hi"),
+			String("a", "1"),
+			String("a", "2"),
+			String("fields.a", "3"),
+			String("error_causes", "mine"),
+			Error("error", wrapped),
+			String("\xff", "invalid"),
+			String("�", "replacement"),
+			Group("g", String("k", "1"), String("k", "2")),
+			String("service", "user"),
+		},
+	}
+
+	for name, f := range jsonFormatters("2006", String("service", "static"), String("service", "again")) {
+		t.Run(name, func(t *testing.T) {
+			if err := checkJSON(f.Format(nil, r)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(JSON{}.Format(nil, r), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"level":               "INFO",
+		"fields.level":        "hi",
+		"a":                   "1",
+		"fields.a":            "2",
+		"fields.fields.a":     "3",
+		"error_causes":        "mine",
+		"error":               "save: disk full",
+		"fields.error_causes": []any{"disk full"},
+		"�":                   "invalid",
+		"fields.�":            "replacement",
+		"g":                   map[string]any{"k": "1", "fields.k": "2"},
+	}
+	for key, value := range want {
+		if fmt.Sprint(got[key]) != fmt.Sprint(value) {
+			t.Errorf("%q = %v, want %v", key, got[key], value)
+		}
+	}
+}
+
+func FuzzJSON(f *testing.F) {
+	f.Add("level", "a", "hi", "msg", "main.go:1", "db", "2006-01-02", 1.5)
+	f.Add("message", "message", "\x00\"\\ ", "\xff", "", "", `"`, math.NaN())
+	f.Add("fields.a", "a", "", "", "x:y:z", "\n", TimeUnixNano, math.Inf(1))
+	f.Add("error", "error_causes", "�", " ", "file.go:0", "a.b", "", math.Inf(-1))
+
+	f.Fuzz(func(t *testing.T, key1, key2, value, msg, caller, name, timeFormat string, num float64) {
+		err := fmt.Errorf("%s: %w", value, errors.New(msg))
+		r := &Record{
+			Time:    time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			Level:   Level{Value: 20, Name: value},
+			Message: msg,
+			Name:    name,
+			Caller:  caller,
+			Fields: []Field{
+				String(key1, value),
+				String(key2, value),
+				Float64(key1, num),
+				Float32(key2, float32(num)),
+				Any(key1, num),
+				Error(key1, err),
+				Error(key2, err),
+				String(key1+"_causes", value),
+				Group(key2, String(key1, value), Float64(key1, num), Error(key2, err)),
+				Any(key2, map[string]string{key1: value}),
+				Any(value, []any{key1, num}),
+				Duration(key1, time.Duration(num)),
+				Time(key2, time.Unix(0, int64(num))),
+			},
+		}
+
+		for preset, j := range jsonFormatters(timeFormat, String(key1, value), String(key2, msg)) {
+			if err := checkJSON(j.Format(nil, r)); err != nil {
+				t.Fatalf("%s: %v", preset, err)
+			}
+		}
+	})
+}
+
+func TestOTelPreset(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	r := &Record{
+		Time:    ts,
+		Level:   Level{Value: 40, Name: "ERROR"},
+		Message: "failed",
+		Name:    "api",
+		Caller:  "main.go:12",
+		Fields:  []Field{Int64("n", 1)},
+	}
+	line := OTel().Format(nil, r)
+	if err := checkJSON(line); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(line, &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := "1704164645123456789"; got["Timestamp"] != want {
+		t.Errorf("Timestamp = %#v, want %q", got["Timestamp"], want)
+	}
+	want := `{"Timestamp":"1704164645123456789","SeverityText":"ERROR","SeverityNumber":17,"Body":"failed",` +
+		`"Attributes":{"logger.name":"api","n":1,"code.filepath":"main.go","code.lineno":12}}` + "\n"
+	if string(line) != want {
+		t.Errorf("got  %s\nwant %s", line, want)
+	}
+}
+
+func TestLowerLevel(t *testing.T) {
+	for name, want := range map[string]string{"INFO": "info", "SUCCESS": "success", "AUDIT": "audit", "": ""} {
+		if got := LowerLevel(Level{Name: name}); got != want {
+			t.Errorf("LowerLevel(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
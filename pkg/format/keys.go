@@ -0,0 +1,53 @@
+package format
+
+import "bytes"
+
+const fieldPrefix = "fields."
+
+// keySet tracks the keys of one object or line so that a field key that
+// repeats a reserved or earlier key can be renamed. Keys are compared as
+// written to dst, after escaping, and the first 32 are held without
+// allocating.
+type keySet struct {
+	reserved  [7]string
+	nreserved int
+	spans     [32][2]int
+	n         int
+	more      [][2]int
+}
+
+func (k *keySet) reserve(key string) {
+	if key != "" {
+		k.reserved[k.nreserved] = key
+		k.nreserved++
+	}
+}
+
+func (k *keySet) add(start, end int) {
+	if k.n < len(k.spans) {
+		k.spans[k.n] = [2]int{start, end}
+	} else {
+		k.more = append(k.more, [2]int{start, end})
+	}
+	k.n++
+}
+
+func (k *keySet) taken(dst []byte, start int) bool {
+	key := dst[start:]
+	for _, r := range k.reserved[:k.nreserved] {
+		if string(key) == r {
+			return true
+		}
+	}
+	for _, s := range k.spans[:min(k.n, len(k.spans))] {
+		if bytes.Equal(dst[s[0]:s[1]], key) {
+			return true
+		}
+	}
+	for _, s := range k.more {
+		if bytes.Equal(dst[s[0]:s[1]], key) {
+			return true
+		}
+	}
+	return false
+}
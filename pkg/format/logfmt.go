@@ -0,0 +1,280 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Summaw/aurora/pkg/color"
+)
+
+type Logfmt struct {
+	TimeFormat string
+	Color      bool
+
+	profile    color.Profile
+	hasProfile bool
+}
+
+func (l Logfmt) WithProfile(profile color.Profile) Formatter {
+	l.profile, l.hasProfile = profile, true
+	return l
+}
+
+func (l Logfmt) Format(dst []byte, r *Record) []byte {
+	l.profile, l.hasProfile = l.colorProfile(), true
+
+	tf := l.TimeFormat
+	if tf == "" {
+		tf = time.RFC3339Nano
+	}
+
+	var scratch [64]byte
+	if !r.Time.IsZero() {
+		dst = l.appendKey(dst, "time")
+		dst = appendLogfmtValue(dst, r.Time.AppendFormat(scratch[:0], tf))
+		dst = append(dst, ' ')
+	}
+
+	dst = l.appendKey(dst, "level")
+	if l.colored() {
+		dst = l.startLevel(dst, r.Level)
+	}
+	dst = appendLogfmtValue(dst, appendLower(scratch[:0], r.Level.Name))
+	if l.colored() {
+		dst = append(dst, color.Reset...)
+	}
+
+	if r.Name != "" {
+		dst = append(dst, ' ')
+		dst = l.appendKey(dst, "logger")
+		dst = appendLogfmtValue(dst, []byte(r.Name))
+	}
+
+	dst = append(dst, ' ')
+	dst = l.appendKey(dst, "msg")
+	dst = appendLogfmtValue(dst, []byte(r.Message))
+
+	keys := keySet{reserved: [7]string{"time", "level", "logger", "msg", "caller"}, nreserved: 5}
+	for _, field := range r.Fields {
+		dst = l.appendField(dst, &keys, "", field, 0)
+	}
+
+	if r.Caller != "" {
+		dst = append(dst, ' ')
+		dst = l.appendKey(dst, "caller")
+		dst = appendLogfmtValue(dst, []byte(r.Caller))
+	}
+
+	return append(dst, '\n')
+}
+
+func (l Logfmt) appendField(dst []byte, keys *keySet, prefix string, field Field, depth int) []byte {
+	key := field.Key
+	if prefix != "" {
+		if strings.HasPrefix(key, "[") {
+			key = prefix + key
+		} else {
+			key = prefix + "." + key
+		}
+	}
+
+	if field.Kind == KindAny {
+		if stack, ok := field.value.(StackTrace); ok {
+			dst = append(dst, ' ')
+			dst, _ = l.appendFieldKey(dst, keys, key, 0)
+			return appendLogfmtValue(dst, appendStackText(nil, stack))
+		}
+		if children, ok := prettyChildren(field, depth); ok {
+			for _, child := range children {
+				dst = l.appendField(dst, keys, key, child, depth+1)
+			}
+			return dst
+		}
+	}
+
+	dst = append(dst, ' ')
+	dst, prefixes := l.appendFieldKey(dst, keys, key, 0)
+	var scratch [64]byte
+	dst = appendLogfmtValue(dst, field.AppendText(scratch[:0]))
+
+	if field.Kind == KindError {
+		err, _ := field.value.(error)
+		for i, cause := range Causes(err) {
+			dst = append(dst, ' ')
+			dst, _ = l.appendFieldKey(dst, keys, key+"_causes["+strconv.Itoa(i)+"]", prefixes)
+			dst = appendLogfmtValue(dst, []byte(cause.Error()))
+		}
+		if stack := ErrorStack(err); len(stack) > 0 {
+			dst = append(dst, ' ')
+			dst, _ = l.appendFieldKey(dst, keys, key+"_stack", prefixes)
+			dst = appendLogfmtValue(dst, appendStackText(nil, stack))
+		}
+	}
+	return dst
+}
+
+func (l Logfmt) colorProfile() color.Profile {
+	if !l.Color {
+		return color.NoColor
+	}
+	if profile, ok := color.ProfileOverride(); ok {
+		return profile
+	}
+	if l.hasProfile {
+		return l.profile
+	}
+	return color.CurrentProfile()
+}
+
+func (l Logfmt) colored() bool {
+	return l.profile != color.NoColor
+}
+
+func (l Logfmt) appendKey(dst []byte, key string) []byte {
+	if l.colored() {
+		dst = color.Gray.AppendProfile(dst, l.profile)
+		dst = appendLogfmtKey(dst, key)
+		dst = append(dst, color.Reset...)
+		return append(dst, '=')
+	}
+	dst = appendLogfmtKey(dst, key)
+	return append(dst, '=')
+}
+
+func (l Logfmt) appendFieldKey(dst []byte, keys *keySet, key string, prefixes int) ([]byte, int) {
+	if l.colored() {
+		dst = color.Gray.AppendProfile(dst, l.profile)
+	}
+	start := len(dst)
+	for ; ; prefixes++ {
+		dst = dst[:start]
+		for i := 0; i < prefixes; i++ {
+			dst = append(dst, fieldPrefix...)
+		}
+		dst = appendLogfmtKey(dst, key)
+		if !keys.taken(dst, start) {
+			break
+		}
+	}
+	keys.add(start, len(dst))
+	if l.colored() {
+		dst = append(dst, color.Reset...)
+	}
+	return append(dst, '='), prefixes
+}
+
+func (l Logfmt) startLevel(dst []byte, level Level) []byte {
+	if level.Bold {
+		dst = append(dst, color.Bold...)
+	}
+	return level.Color.AppendProfile(dst, l.profile)
+}
+
+func appendLower(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		dst = append(dst, c)
+	}
+	return dst
+}
+
+func appendStackText(dst []byte, stack StackTrace) []byte {
+	for i, frame := range stack.Frames() {
+		if i > 0 {
+			dst = append(dst, '\n')
+		}
+		dst = appendFrame(dst, frame)
+	}
+	return dst
+}
+
+func appendLogfmtKey(dst []byte, key string) []byte {
+	if key == "" {
+		return append(dst, '_')
+	}
+	for i := 0; i < len(key); {
+		c := key[i]
+		if c < utf8.RuneSelf {
+			if c <= ' ' || c == '=' || c == '"' || c == 0x7f {
+				dst = append(dst, '_')
+			} else {
+				dst = append(dst, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(key[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, '_')
+		} else {
+			dst = append(dst, key[i:i+size]...)
+		}
+		i += size
+	}
+	return dst
+}
+
+func appendLogfmtValue(dst, s []byte) []byte {
+	if !logfmtNeedsQuote(s) {
+		return append(dst, s...)
+	}
+
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				dst = append(dst, '\\', c)
+			case c == '\n':
+				dst = append(dst, '\\', 'n')
+			case c == '\r':
+				dst = append(dst, '\\', 'r')
+			case c == '\t':
+				dst = append(dst, '\\', 't')
+			case c < 0x20 || c == 0x7f:
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			default:
+				dst = append(dst, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, "\uFFFD"...)
+		} else {
+			dst = append(dst, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(dst, '"')
+}
+
+func logfmtNeedsQuote(s []byte) bool {
+	if len(s) == 0 {
+		return true
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c <= ' ' || c == '=' || c == '"' || c == '\\' || c == 0x7f {
+				return true
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			return true
+		}
+		i += size
+	}
+	return false
+}
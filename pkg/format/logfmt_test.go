@@ -0,0 +1,208 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type logfmtPair struct {
+	key, value string
+}
+
+func parseLogfmt(line string) ([]logfmtPair, error) {
+	line = strings.TrimSuffix(line, "\n")
+	if strings.ContainsAny(line, "\n\r") {
+		return nil, fmt.Errorf("line break inside line %q", line)
+	}
+
+	var pairs []logfmtPair
+	for i := 0; i < len(line); {
+		eq := strings.IndexByte(line[i:], '=')
+		if eq <= 0 {
+			return nil, fmt.Errorf("missing key at %d in %q", i, line)
+		}
+		key := line[i : i+eq]
+		if strings.ContainsAny(key, ` "`) {
+			return nil, fmt.Errorf("bad key %q in %q", key, line)
+		}
+		i += eq + 1
+
+		var value string
+		if i < len(line) && line[i] == '"' {
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, fmt.Errorf("unterminated value for %q in %q", key, line)
+			}
+			v, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("value for %q: %v", key, err)
+			}
+			value, i = v, end+1
+		} else {
+			end := strings.IndexByte(line[i:], ' ')
+			if end < 0 {
+				end = len(line) - i
+			}
+			value = line[i : i+end]
+			if value == "" || strings.ContainsAny(value, `="\`) {
+				return nil, fmt.Errorf("bad bare value %q for %q", value, key)
+			}
+			i += end
+		}
+		pairs = append(pairs, logfmtPair{key, value})
+
+		if i < len(line) {
+			if line[i] != ' ' {
+				return nil, fmt.Errorf("missing space after %q in %q", key, line)
+			}
+			i++
+		}
+	}
+	return pairs, nil
+}
+
+func formatLogfmt(t *testing.T, r *Record) map[string]string {
+	t.Helper()
+	line := string(Logfmt{}.Format(nil, r))
+	pairs, err := parseLogfmt(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		if _, dup := m[p.key]; dup {
+			t.Fatalf("duplicate key %q in %q", p.key, line)
+		}
+		m[p.key] = p.value
+	}
+	return m
+}
+
+func logfmtRecord(fields ...Field) *Record {
+	return &Record{
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		Level:   Level{Value: 20, Name: "INFO"},
+		Message: "user created",
+		Fields:  fields,
+	}
+}
+
+func TestLogfmtRoundTrip(t *testing.T) {
+	err := fmt.Errorf("save: %w", errors.New("disk full"))
+	r := logfmtRecord(
+		String("plain", "abc"),
+		String("space", "a b"),
+		String("quote", `say "hi"`),
+		String("equals", "a=b"),
+		String("backslash", `C:\tmp`),
+		String("control", "a\nb\r\tc\x00\x1b\x7f"),
+		String("invalid", "a\xffb"),
+		String("empty", ""),
+		String("unicode", "héllo ✓"),
+		String("a key=\"x\"", "v"),
+		Int64("n", -42),
+		Bool("ok", true),
+		Duration("took", 1500*time.Millisecond),
+		Group("db", String("host", "localhost"), Int64("port", 5432), Group("pool", Int64("size", 4))),
+		Any("tags", []string{"a", "b c"}),
+		Any("user", map[string]any{"id": 7}),
+		Error("err", err),
+	)
+	r.Name = "api"
+	r.Caller = "main.go:12"
+	r.Message = "line one\nline \"two\""
+
+	got := formatLogfmt(t, r)
+	want := map[string]string{
+		"time":          "2024-01-02T03:04:05.000000006Z",
+		"level":         "info",
+		"logger":        "api",
+		"msg":           "line one\nline \"two\"",
+		"caller":        "main.go:12",
+		"plain":         "abc",
+		"space":         "a b",
+		"quote":         `say "hi"`,
+		"equals":        "a=b",
+		"backslash":     `C:\tmp`,
+		"control":       "a\nb\r\tc\x00\x1b\x7f",
+		"invalid":       "a\ufffdb",
+		"empty":         "",
+		"unicode":       "héllo ✓",
+		"a_key__x_":     "v",
+		"n":             "-42",
+		"ok":            "true",
+		"took":          "1.50s",
+		"db.host":       "localhost",
+		"db.port":       "5432",
+		"db.pool.size":  "4",
+		"tags[0]":       "a",
+		"tags[1]":       "b c",
+		"user.id":       "7",
+		"err":           "save: disk full",
+		"err_causes[0]": "disk full",
+	}
+	for key, value := range want {
+		if v, ok := got[key]; !ok {
+			t.Errorf("missing key %q", key)
+		} else if v != value {
+			t.Errorf("%s = %q, want %q", key, v, value)
+		}
+	}
+	for key := range got {
+		if _, ok := want[key]; !ok {
+			t.Errorf("unexpected key %q", key)
+		}
+	}
+}
+
+func TestLogfmtReservedKeys(t *testing.T) {
+	r := logfmtRecord(
+		String("level", "error"),
+		String("msg", "spoofed"),
+		String("time", "never"),
+		String("logger", "other"),
+		String("caller", "x.go:1"),
+		String("a", "1"),
+		String("a", "2"),
+		String("a", "3"),
+		String("err_causes[0]", "mine"),
+		Error("err", fmt.Errorf("wrap: %w", errors.New("cause"))),
+	)
+	r.Caller = "main.go:12"
+
+	got := formatLogfmt(t, r)
+	want := map[string]string{
+		"level":                "info",
+		"msg":                  "user created",
+		"caller":               "main.go:12",
+		"fields.level":         "error",
+		"fields.msg":           "spoofed",
+		"fields.time":          "never",
+		"fields.logger":        "other",
+		"fields.caller":        "x.go:1",
+		"a":                    "1",
+		"fields.a":             "2",
+		"fields.fields.a":      "3",
+		"err_causes[0]":        "mine",
+		"err":                  "wrap: cause",
+		"fields.err_causes[0]": "cause",
+	}
+	for key, value := range want {
+		if v := got[key]; v != value {
+			t.Errorf("%s = %q, want %q", key, v, value)
+		}
+	}
+	if _, ok := got["logger"]; ok {
+		t.Errorf("unnamed record wrote a logger key: %v", got)
+	}
+}
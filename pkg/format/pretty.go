@@ -0,0 +1,177 @@
+package format
+
+import (
+	"bytes"
+
+	"github.com/Summaw/aurora/pkg/color"
+)
+
+const DefaultTimeFormat = "15:04:05.000"
+
+const prettyIndent = "            "
+
+type Pretty struct {
+	TimeFormat string
+	NoColor    bool
+
+	profile    color.Profile
+	hasProfile bool
+}
+
+func (p Pretty) WithProfile(profile color.Profile) Formatter {
+	p.profile, p.hasProfile = profile, true
+	return p
+}
+
+func (p Pretty) Format(dst []byte, r *Record) []byte {
+	p.profile, p.hasProfile = p.colorProfile(), true
+
+	tf := p.TimeFormat
+	if tf == "" {
+		tf = DefaultTimeFormat
+	}
+
+	dst = append(dst, "\n  "...)
+	dst = p.startColor(dst, color.DimGray, false)
+	dst = r.Time.AppendFormat(dst, tf)
+	dst = p.endColor(dst)
+	dst = append(dst, "  "...)
+
+	dst = p.startColor(dst, r.Level.Color, r.Level.Bold)
+	dst = append(dst, r.Level.Icon...)
+	dst = append(dst, ' ')
+	dst = append(dst, r.Level.Name...)
+	dst = p.endColor(dst)
+
+	dst = append(dst, "  "...)
+	if r.Name != "" {
+		dst = p.startColor(dst, color.Gray, false)
+		dst = append(dst, '[')
+		dst = append(dst, r.Name...)
+		dst = append(dst, "] "...)
+		dst = p.endColor(dst)
+	}
+	dst = append(dst, r.Message...)
+	dst = append(dst, '\n')
+
+	dst = p.appendFields(dst, r.Fields, prettyIndent, r.Caller == "", 0)
+
+	if r.Caller != "" {
+		dst = append(dst, prettyIndent...)
+		dst = p.colorize(dst, "└─", color.DimGray)
+		dst = append(dst, ' ')
+		dst = p.colorize(dst, "at:", color.Gray)
+		dst = append(dst, ' ')
+		dst = p.colorize(dst, r.Caller, color.DimGray)
+		dst = append(dst, '\n')
+	}
+
+	return dst
+}
+
+func (p Pretty) appendFields(dst []byte, fields []Field, indent string, closed bool, depth int) []byte {
+	for i, field := range fields {
+		last := i == len(fields)-1 && closed
+
+		dst = append(dst, indent...)
+		if last {
+			dst = p.colorize(dst, "└─", color.DimGray)
+		} else {
+			dst = p.colorize(dst, "├─", color.DimGray)
+		}
+		dst = append(dst, ' ')
+		dst = p.startColor(dst, color.Gray, false)
+		dst = append(dst, field.Key...)
+		dst = append(dst, ':')
+		dst = p.endColor(dst)
+
+		if children, ok := prettyChildren(field, depth); ok {
+			dst = append(dst, '\n')
+			dst = p.appendFields(dst, children, p.childIndent(indent, last), true, depth+1)
+			continue
+		}
+
+		dst = append(dst, ' ')
+		start := len(dst)
+		dst = field.AppendText(dst)
+		if bytes.IndexByte(dst[start:], '\n') >= 0 {
+			dst = p.indentLines(dst, start, p.childIndent(indent, last))
+		}
+		dst = append(dst, '\n')
+
+		if field.Kind == KindError && depth < maxExpandDepth {
+			dst = p.appendFields(dst, field.errorChildren(), p.childIndent(indent, last), true, depth+1)
+		}
+	}
+	return dst
+}
+
+// indentLines re-indents the line breaks in dst[start:] with the branch's
+// continuation prefix so that multi-line values stay inside the tree.
+func (p Pretty) indentLines(dst []byte, start int, indent string) []byte {
+	lines := bytes.Split(bytes.TrimRight(dst[start:], "\n"), []byte{'\n'})
+	text := bytes.Join(lines, append([]byte{'\n'}, indent...))
+	return append(dst[:start], text...)
+}
+
+func (p Pretty) childIndent(indent string, last bool) string {
+	if last {
+		return indent + "   "
+	}
+	if p.profile == color.NoColor {
+		return indent + "│  "
+	}
+	return indent + string(p.colorize(nil, "│", color.DimGray)) + "  "
+}
+
+func (p Pretty) colorProfile() color.Profile {
+	if p.NoColor {
+		return color.NoColor
+	}
+	if profile, ok := color.ProfileOverride(); ok {
+		return profile
+	}
+	if p.hasProfile {
+		return p.profile
+	}
+	return color.CurrentProfile()
+}
+
+func (p Pretty) startColor(dst []byte, c color.RGB, bold bool) []byte {
+	if p.profile == color.NoColor {
+		return dst
+	}
+	if bold {
+		dst = append(dst, color.Bold...)
+	}
+	return c.AppendProfile(dst, p.profile)
+}
+
+func (p Pretty) endColor(dst []byte) []byte {
+	if p.profile == color.NoColor {
+		return dst
+	}
+	return append(dst, color.Reset...)
+}
+
+func (p Pretty) colorize(dst []byte, text string, c color.RGB) []byte {
+	dst = p.startColor(dst, c, false)
+	dst = append(dst, text...)
+	return p.endColor(dst)
+}
+
+func prettyChildren(field Field, depth int) ([]Field, bool) {
+	if field.Kind != KindAny {
+		return nil, false
+	}
+	switch val := field.value.(type) {
+	case []Field:
+		return val, true
+	case StackTrace:
+		return val.fields(), true
+	}
+	if depth >= maxExpandDepth {
+		return nil, false
+	}
+	return expandValue(field.value)
+}
@@ -0,0 +1,57 @@
+package format
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Summaw/aurora/pkg/color"
+)
+
+func colorRecord() *Record {
+	return &Record{
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   Level{Value: 20, Name: "INFO", Color: color.RGB{R: 96, G: 165, B: 250}},
+		Message: "hello",
+		Fields:  []Field{Group("http", String("method", "GET"))},
+	}
+}
+
+func TestWithProfile(t *testing.T) {
+	if _, ok := color.ProfileOverride(); ok {
+		t.Skip("a global color profile override is set")
+	}
+
+	tests := []struct {
+		name string
+		f    ColorFormatter
+		p    color.Profile
+		want string
+	}{
+		{"PrettyNone", Pretty{}, color.NoColor, ""},
+		{"Pretty256", Pretty{}, color.ANSI256, "\x1b[38;5;"},
+		{"PrettyTrueColor", Pretty{}, color.TrueColor, "\x1b[38;2;"},
+		{"PrettyNoColorWins", Pretty{NoColor: true}, color.TrueColor, ""},
+		{"Logfmt16", Logfmt{Color: true}, color.ANSI16, "\x1b[9"},
+		{"LogfmtColorOff", Logfmt{}, color.TrueColor, ""},
+		{"CommonLogFallback", CommonLog{}, color.ANSI256, "\x1b[38;5;"},
+		{"CommonLogFallbackNone", CommonLog{}, color.NoColor, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := string(tt.f.WithProfile(tt.p).Format(nil, colorRecord()))
+			if tt.want == "" {
+				if strings.Contains(out, "\x1b[") {
+					t.Fatalf("unexpected escape codes: %q", out)
+				}
+				return
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Fatalf("output lacks %q: %q", tt.want, out)
+			}
+			if strings.Contains(out, "\x1b[38;2;") && tt.p != color.TrueColor {
+				t.Fatalf("truecolor codes with profile %v: %q", tt.p, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package format
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxExpandDepth = 8
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+func Expand(v any) ([]Field, bool) {
+	return expandValue(v)
+}
+
+func expandValue(v any) ([]Field, bool) {
+	if v == nil {
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() || opaque(rv.Type()) {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+	if opaque(rv.Type()) {
+		return nil, false
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Len() == 0 {
+			return nil, false
+		}
+		keys := rv.MapKeys()
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = fmt.Sprint(k.Interface())
+		}
+		sort.Sort(byName{keys, names})
+		fields := make([]Field, len(keys))
+		for i, k := range keys {
+			fields[i] = Any(names[i], rv.MapIndex(k).Interface())
+		}
+		return fields, true
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 || rv.Type().Elem().Kind() == reflect.Uint8 {
+			return nil, false
+		}
+		fields := make([]Field, rv.Len())
+		for i := range fields {
+			fields[i] = Any("["+strconv.Itoa(i)+"]", rv.Index(i).Interface())
+		}
+		return fields, true
+	case reflect.Struct:
+		t := rv.Type()
+		var fields []Field
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name := sf.Name
+			if tag, ok := sf.Tag.Lookup("json"); ok {
+				tagName, _, _ := strings.Cut(tag, ",")
+				if tagName == "-" {
+					continue
+				}
+				if tagName != "" {
+					name = tagName
+				}
+			}
+			fields = append(fields, Any(name, rv.Field(i).Interface()))
+		}
+		return fields, len(fields) > 0
+	}
+	return nil, false
+}
+
+func (f Field) Snapshot() Field {
+	if f.Kind != KindAny {
+		return f
+	}
+	f.value = snapshotValue(f.value)
+	return f
+}
+
+func snapshotValue(v any) any {
+	switch val := v.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		float32, float64, time.Time, time.Duration, error, StackTrace, rendered:
+		return v
+	case []Field:
+		fields := make([]Field, len(val))
+		for i, f := range val {
+			fields[i] = f.Snapshot()
+		}
+		return fields
+	}
+
+	rv := reflect.ValueOf(v)
+	if opaque(rv.Type()) {
+		return rendered{
+			json: appendJSONValue(nil, Any("", v)),
+			text: fmt.Sprint(v),
+		}
+	}
+	return deepCopy(rv, 0).Interface()
+}
+
+func deepCopy(rv reflect.Value, depth int) reflect.Value {
+	if depth >= maxExpandDepth {
+		return rv
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return rv
+		}
+		cp := reflect.New(rv.Type().Elem())
+		cp.Elem().Set(deepCopy(rv.Elem(), depth+1))
+		return cp
+	case reflect.Interface:
+		if rv.IsNil() {
+			return rv
+		}
+		cp := reflect.New(rv.Type()).Elem()
+		cp.Set(deepCopy(rv.Elem(), depth+1))
+		return cp
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv
+		}
+		cp := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), deepCopy(iter.Value(), depth+1))
+		}
+		return cp
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+		cp := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		reflect.Copy(cp, rv)
+		for i := 0; i < rv.Len(); i++ {
+			cp.Index(i).Set(deepCopy(rv.Index(i), depth+1))
+		}
+		return cp
+	case reflect.Array:
+		cp := reflect.New(rv.Type()).Elem()
+		cp.Set(rv)
+		for i := 0; i < rv.Len(); i++ {
+			cp.Index(i).Set(deepCopy(rv.Index(i), depth+1))
+		}
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(rv.Type()).Elem()
+		cp.Set(rv)
+		for i := 0; i < rv.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(deepCopy(rv.Field(i), depth+1))
+			}
+		}
+		return cp
+	}
+	return rv
+}
+
+// rendered holds a marshaler or stringer already formatted by Snapshot, so
+// formatters print what the value looked like when it was logged.
+type rendered struct {
+	json []byte
+	text string
+}
+
+func (r rendered) MarshalJSON() ([]byte, error) {
+	return r.json, nil
+}
+
+func (r rendered) String() string {
+	return r.text
+}
+
+func opaque(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) ||
+		t.Implements(stringerType) ||
+		t.Implements(errorType)
+}
+
+type byName struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (b byName) Len() int           { return len(b.names) }
+func (b byName) Less(i, j int) bool { return b.names[i] < b.names[j] }
+func (b byName) Swap(i, j int) {
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+	b.names[i], b.names[j] = b.names[j], b.names[i]
+}
+
+func appendJSONMarshal(dst []byte, v any) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return appendJSONString(dst, fmt.Sprintf("%v", v))
+	}
+	return append(dst, bytes.TrimRight(buf.Bytes(), "\n")...)
+}
@@ -0,0 +1,393 @@
+package rotate
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+type Option func(*File)
+
+func WithMaxSize(bytes int64) Option {
+	return func(f *File) {
+		f.maxSize = bytes
+	}
+}
+
+func WithMaxAge(d time.Duration) Option {
+	return func(f *File) {
+		f.maxAge = d
+	}
+}
+
+func WithMaxBackups(n int) Option {
+	return func(f *File) {
+		f.maxBackups = n
+	}
+}
+
+func WithCompress(enabled bool) Option {
+	return func(f *File) {
+		f.compress = enabled
+	}
+}
+
+func WithDaily(enabled bool) Option {
+	return func(f *File) {
+		f.daily = enabled
+	}
+}
+
+func WithReopenSignal(enabled bool) Option {
+	return func(f *File) {
+		f.reopenSignal = enabled
+	}
+}
+
+type File struct {
+	filename     string
+	maxSize      int64
+	maxAge       time.Duration
+	maxBackups   int
+	compress     bool
+	daily        bool
+	reopenSignal bool
+
+	mu       sync.Mutex
+	millMu   sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	signals  chan os.Signal
+	done     chan struct{}
+}
+
+func New(filename string, opts ...Option) (*File, error) {
+	f := &File{filename: filename}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return nil, err
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	go f.mill()
+
+	if f.reopenSignal && len(reopenSignals) > 0 {
+		f.signals = make(chan os.Signal, 1)
+		f.done = make(chan struct{})
+		signal.Notify(f.signals, reopenSignals...)
+		go f.watchSignals(f.signals, f.done)
+	}
+
+	return f, nil
+}
+
+func (f *File) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.shouldRotate(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *File) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rotate()
+}
+
+func (f *File) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.closeFile(); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+func (f *File) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
+
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.signals != nil {
+		signal.Stop(f.signals)
+		close(f.done)
+		f.signals = nil
+	}
+	return f.closeFile()
+}
+
+func (f *File) watchSignals(signals <-chan os.Signal, done <-chan struct{}) {
+	for {
+		select {
+		case <-signals:
+			f.mu.Lock()
+			if f.file != nil {
+				if err := f.closeFile(); err == nil {
+					f.open()
+				}
+			}
+			f.mu.Unlock()
+		case <-done:
+			return
+		}
+	}
+}
+
+func (f *File) shouldRotate(n int64) bool {
+	if f.maxSize > 0 && f.size > 0 && f.size+n > f.maxSize {
+		return true
+	}
+	if f.daily {
+		now := time.Now()
+		y1, m1, d1 := f.openedAt.Date()
+		y2, m2, d2 := now.Date()
+		return y1 != y2 || m1 != m2 || d1 != d2
+	}
+	return false
+}
+
+func (f *File) open() error {
+	file, err := os.OpenFile(f.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+	if f.size > 0 {
+		f.openedAt = info.ModTime()
+	}
+	return nil
+}
+
+func (f *File) closeFile() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *File) rotate() error {
+	if err := f.closeFile(); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(f.filename); err == nil {
+		if err := os.Rename(f.filename, f.backupName(time.Now())); err != nil {
+			return err
+		}
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	go f.mill()
+	return nil
+}
+
+func (f *File) backupName(t time.Time) string {
+	dir := filepath.Dir(f.filename)
+	prefix, ext := f.nameParts()
+	stamp := t.Format(backupTimeFormat)
+
+	name := filepath.Join(dir, prefix+stamp+ext)
+	for seq := 1; exists(name) || exists(name+".gz"); seq++ {
+		name = filepath.Join(dir, prefix+stamp+"-"+strconv.Itoa(seq)+ext)
+	}
+	return name
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+func (f *File) nameParts() (prefix, ext string) {
+	base := filepath.Base(f.filename)
+	ext = filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-", ext
+}
+
+type backup struct {
+	path      string
+	timestamp time.Time
+	seq       int
+}
+
+func (f *File) backups() ([]backup, error) {
+	dir := filepath.Dir(f.filename)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, ext := f.nameParts()
+	var result []backup
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimPrefix(name, prefix)
+		stamp = strings.TrimSuffix(stamp, ".gz")
+		if !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		t, seq, ok := parseStamp(strings.TrimSuffix(stamp, ext))
+		if !ok {
+			continue
+		}
+		result = append(result, backup{path: filepath.Join(dir, name), timestamp: t, seq: seq})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].timestamp.Equal(result[j].timestamp) {
+			return result[i].timestamp.After(result[j].timestamp)
+		}
+		return result[i].seq > result[j].seq
+	})
+	return result, nil
+}
+
+func parseStamp(stamp string) (time.Time, int, bool) {
+	if len(stamp) < len(backupTimeFormat) {
+		return time.Time{}, 0, false
+	}
+	t, err := time.ParseInLocation(backupTimeFormat, stamp[:len(backupTimeFormat)], time.Local)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+
+	rest := stamp[len(backupTimeFormat):]
+	if rest == "" {
+		return t, 0, true
+	}
+	if rest[0] != '-' {
+		return time.Time{}, 0, false
+	}
+	seq, err := strconv.Atoi(rest[1:])
+	if err != nil || seq < 1 {
+		return time.Time{}, 0, false
+	}
+	return t, seq, true
+}
+
+func (f *File) mill() {
+	f.millMu.Lock()
+	defer f.millMu.Unlock()
+
+	files, err := f.backups()
+	if err != nil {
+		return
+	}
+
+	var keep []backup
+	for i, b := range files {
+		if f.maxBackups > 0 && i >= f.maxBackups {
+			os.Remove(b.path)
+			continue
+		}
+		if f.maxAge > 0 && time.Since(b.timestamp) > f.maxAge {
+			os.Remove(b.path)
+			continue
+		}
+		keep = append(keep, b)
+	}
+
+	if !f.compress {
+		return
+	}
+	for _, b := range keep {
+		if strings.HasSuffix(b.path, ".gz") {
+			continue
+		}
+		if err := compressFile(b.path); err != nil {
+			fmt.Fprintf(os.Stderr, "rotate: compress %s: %v\n", b.path, err)
+		}
+	}
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	src.Close()
+	return os.Remove(path)
+}
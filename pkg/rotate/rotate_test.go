@@ -0,0 +1,248 @@
+package rotate
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func listBackups(t *testing.T, f *File) []backup {
+	t.Helper()
+	files, err := f.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestMaxSize(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	f, err := New(name, WithMaxSize(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := readFile(t, name); got != "third\n" {
+		t.Fatalf("current file = %q, want %q", got, "third\n")
+	}
+	files := listBackups(t, f)
+	if len(files) != 2 {
+		t.Fatalf("backups = %d, want 2", len(files))
+	}
+	if got := readFile(t, files[0].path); got != "second\n" {
+		t.Fatalf("newest backup = %q, want %q", got, "second\n")
+	}
+	if got := readFile(t, files[1].path); got != "first\n" {
+		t.Fatalf("oldest backup = %q, want %q", got, "first\n")
+	}
+}
+
+func TestRotateSameInstant(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	f, err := New(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	const n = 20
+	for i := 0; i < n; i++ {
+		if _, err := f.Write([]byte{'a' + byte(i), '\n'}); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := listBackups(t, f)
+	if len(files) != n {
+		t.Fatalf("backups = %d, want %d", len(files), n)
+	}
+	for i, b := range files {
+		want := string([]byte{'a' + byte(n-1-i), '\n'})
+		if got := readFile(t, b.path); got != want {
+			t.Fatalf("backup %d (%s) = %q, want %q", i, filepath.Base(b.path), got, want)
+		}
+	}
+}
+
+func TestDaily(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	f, err := New(name, WithDaily(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	f.Write([]byte("today\n"))
+	if files := listBackups(t, f); len(files) != 0 {
+		t.Fatalf("backups = %d, want 0", len(files))
+	}
+
+	f.mu.Lock()
+	f.openedAt = f.openedAt.AddDate(0, 0, -1)
+	f.mu.Unlock()
+
+	f.Write([]byte("tomorrow\n"))
+	files := listBackups(t, f)
+	if len(files) != 1 {
+		t.Fatalf("backups = %d, want 1", len(files))
+	}
+	if got := readFile(t, files[0].path); got != "today\n" {
+		t.Fatalf("backup = %q, want %q", got, "today\n")
+	}
+	if got := readFile(t, name); got != "tomorrow\n" {
+		t.Fatalf("current file = %q, want %q", got, "tomorrow\n")
+	}
+}
+
+func TestCompress(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	f, err := New(name, WithCompress(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	f.Write([]byte("compress me\n"))
+	if err := f.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var path string
+	waitFor(t, "compressed backup", func() bool {
+		files, _ := f.backups()
+		if len(files) == 1 && strings.HasSuffix(files[0].path, ".gz") {
+			path = files[0].path
+			return true
+		}
+		return false
+	})
+
+	src, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	gz, err := gzip.NewReader(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "compress me\n" {
+		t.Fatalf("decompressed = %q, want %q", b, "compress me\n")
+	}
+}
+
+func TestMaxBackups(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.log")
+	f, err := New(name, WithMaxBackups(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for i := 0; i < 5; i++ {
+		f.Write([]byte{'a' + byte(i), '\n'})
+		if err := f.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	waitFor(t, "pruned backups", func() bool {
+		files, _ := f.backups()
+		return len(files) == 2
+	})
+	files := listBackups(t, f)
+	if got := readFile(t, files[0].path); got != "e\n" {
+		t.Fatalf("newest backup = %q, want %q", got, "e\n")
+	}
+	if got := readFile(t, files[1].path); got != "d\n" {
+		t.Fatalf("oldest kept backup = %q, want %q", got, "d\n")
+	}
+}
+
+func TestPruneOnOpen(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	old := filepath.Join(dir, "app-"+time.Now().Add(-48*time.Hour).Format(backupTimeFormat)+".log")
+	recent := filepath.Join(dir, "app-"+time.Now().Add(-time.Hour).Format(backupTimeFormat)+".log")
+	for _, path := range []string{old, recent} {
+		if err := os.WriteFile(path, []byte("backup\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := New(name, WithMaxAge(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	waitFor(t, "expired backup removal", func() bool {
+		_, err := os.Stat(old)
+		return os.IsNotExist(err)
+	})
+	if _, err := os.Stat(recent); err != nil {
+		t.Fatalf("recent backup removed: %v", err)
+	}
+}
+
+func TestParseStamp(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 30, 0, 0, time.Local)
+	stamp := now.Format(backupTimeFormat)
+
+	tests := []struct {
+		in  string
+		seq int
+		ok  bool
+	}{
+		{stamp, 0, true},
+		{stamp + "-3", 3, true},
+		{stamp + "-0", 0, false},
+		{stamp + "-x", 0, false},
+		{stamp + "x", 0, false},
+		{"garbage", 0, false},
+	}
+	for _, tt := range tests {
+		got, seq, ok := parseStamp(tt.in)
+		if ok != tt.ok || seq != tt.seq || (ok && !got.Equal(now)) {
+			t.Errorf("parseStamp(%q) = %v, %d, %v; want %v, %d, %v", tt.in, got, seq, ok, now, tt.seq, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package rotate
+
+import (
+	"os"
+	"syscall"
+)
+
+var reopenSignals = []os.Signal{syscall.SIGHUP}
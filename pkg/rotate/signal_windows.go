@@ -0,0 +1,7 @@
+//go:build windows
+
+package rotate
+
+import "os"
+
+var reopenSignals []os.Signal
@@ -0,0 +1,240 @@
+package aurora
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type RedactStrategy int
+
+const (
+	RedactMask RedactStrategy = iota
+	RedactHash
+	RedactLast4
+)
+
+const redactedMask = "[REDACTED]"
+
+const maxRedactDepth = 8
+
+var cardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+
+type Redactor struct {
+	keys     []string
+	patterns []valuePattern
+	strategy RedactStrategy
+}
+
+type valuePattern struct {
+	re    *regexp.Regexp
+	valid func(match string) bool
+}
+
+func NewRedactor(strategy RedactStrategy) *Redactor {
+	return &Redactor{strategy: strategy}
+}
+
+func DefaultRedactor() *Redactor {
+	return NewRedactor(RedactMask).
+		Keys("authorization", "cookie", "set-cookie", "*password*", "*secret*", "*token*", "api_key", "apikey").
+		Cards()
+}
+
+func (r *Redactor) Keys(patterns ...string) *Redactor {
+	for _, p := range patterns {
+		r.keys = append(r.keys, strings.ToLower(p))
+	}
+	return r
+}
+
+func (r *Redactor) Values(patterns ...string) *Redactor {
+	for _, p := range patterns {
+		r.patterns = append(r.patterns, valuePattern{re: regexp.MustCompile(p)})
+	}
+	return r
+}
+
+func (r *Redactor) ValueRegexp(re ...*regexp.Regexp) *Redactor {
+	for _, p := range re {
+		r.patterns = append(r.patterns, valuePattern{re: p})
+	}
+	return r
+}
+
+func (r *Redactor) ValueFunc(re *regexp.Regexp, valid func(match string) bool) *Redactor {
+	r.patterns = append(r.patterns, valuePattern{re: re, valid: valid})
+	return r
+}
+
+func (r *Redactor) Cards() *Redactor {
+	return r.ValueFunc(cardPattern, Luhn)
+}
+
+func Luhn(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && n <= 19 && sum%10 == 0
+}
+
+func (r *Redactor) MatchKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, p := range r.keys {
+		if p == key {
+			return true
+		}
+		if ok, _ := path.Match(p, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) Redact(fields []Field) {
+	for i := range fields {
+		fields[i] = r.redactField(fields[i])
+	}
+}
+
+func (r *Redactor) RedactString(s string) string {
+	for _, p := range r.patterns {
+		if !p.re.MatchString(s) {
+			continue
+		}
+		s = p.re.ReplaceAllStringFunc(s, func(match string) string {
+			if p.valid != nil && !p.valid(match) {
+				return match
+			}
+			return r.replace(match)
+		})
+	}
+	return s
+}
+
+func (r *Redactor) redactField(f Field) Field {
+	if r.MatchKey(f.Key) {
+		return format.String(f.Key, r.replace(string(f.AppendText(nil))))
+	}
+
+	switch f.Kind {
+	case format.KindString, format.KindError:
+		if len(r.patterns) == 0 {
+			return f
+		}
+		text := string(f.AppendText(nil))
+		if redacted := r.RedactString(text); redacted != text {
+			return format.String(f.Key, redacted)
+		}
+		return f
+	case format.KindAny:
+		return r.redactAny(f)
+	}
+	return f
+}
+
+func (r *Redactor) redactAny(f Field) Field {
+	switch val := f.Any().(type) {
+	case []Field:
+		group := append([]Field(nil), val...)
+		r.Redact(group)
+		return format.Group(f.Key, group...)
+	case string:
+		if redacted := r.RedactString(val); redacted != val {
+			return format.String(f.Key, redacted)
+		}
+		return f
+	}
+	if v, ok := r.redactValue(f.Any(), 0); ok {
+		return format.Any(f.Key, v)
+	}
+	return f
+}
+
+func (r *Redactor) redactValue(v any, depth int) (any, bool) {
+	switch val := v.(type) {
+	case string:
+		if redacted := r.RedactString(val); redacted != val {
+			return redacted, true
+		}
+		return v, false
+	case []Field:
+		group := append([]Field(nil), val...)
+		r.Redact(group)
+		return group, true
+	}
+	if depth >= maxRedactDepth {
+		return v, false
+	}
+
+	children, ok := format.Expand(v)
+	if !ok {
+		return v, false
+	}
+
+	changed := false
+	values := make([]any, len(children))
+	for i, child := range children {
+		values[i] = child.Any()
+		if r.MatchKey(child.Key) {
+			values[i] = r.replace(string(child.AppendText(nil)))
+			changed = true
+		} else if redacted, ok := r.redactValue(child.Any(), depth+1); ok {
+			values[i] = redacted
+			changed = true
+		}
+	}
+	if !changed {
+		return v, false
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		return values, true
+	}
+	m := make(map[string]any, len(children))
+	for i, child := range children {
+		m[child.Key] = values[i]
+	}
+	return m, true
+}
+
+func (r *Redactor) replace(s string) string {
+	switch r.strategy {
+	case RedactHash:
+		sum := sha256.Sum256([]byte(s))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	case RedactLast4:
+		runes := []rune(s)
+		if len(runes) <= 4 {
+			return strings.Repeat("*", len(runes))
+		}
+		return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+	default:
+		return redactedMask
+	}
+}
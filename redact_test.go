@@ -0,0 +1,115 @@
+package aurora
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type testCreds struct {
+	User     string
+	Password string
+	Nested   struct {
+		APIKey string `json:"api_key"`
+		Region string
+	}
+}
+
+func TestRedactNestedValues(t *testing.T) {
+	creds := testCreds{User: "bob", Password: "hunter2"}
+	creds.Nested.APIKey = "k-123"
+	creds.Nested.Region = "eu"
+
+	tests := []struct {
+		name  string
+		log   func(e *Entry) *Entry
+		leaks []string
+		keeps []string
+	}{
+		{"Struct", func(e *Entry) *Entry { return e.Any("c", creds) },
+			[]string{"hunter2", "k-123"}, []string{"bob", "eu"}},
+		{"StructPointer", func(e *Entry) *Entry { return e.Any("c", &creds) },
+			[]string{"hunter2", "k-123"}, []string{"bob", "eu"}},
+		{"Array", func(e *Entry) *Entry { return e.Array("arr", map[string]string{"token": "t-1", "id": "7"}) },
+			[]string{"t-1"}, []string{`"id":"7"`}},
+		{"Slice", func(e *Entry) *Entry { return e.Any("list", []any{creds, "plain"}) },
+			[]string{"hunter2"}, []string{"plain", "bob"}},
+		{"MapOfMaps", func(e *Entry) *Entry {
+			return e.Any("m", map[string]any{"outer": map[string]any{"secret_value": "s3cr3t", "ok": 1}})
+		}, []string{"s3cr3t"}, []string{`"ok":1`}},
+		{"ValuePattern", func(e *Entry) *Entry { return e.Any("m", []string{"card 4111 1111 1111 1111"}) },
+			[]string{"1111 1111"}, []string{"card"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log := New(WithOutput(&buf), WithJSON(true), WithRedactor(DefaultRedactor()))
+			tt.log(log.Info("x")).Send()
+
+			out := buf.String()
+			for _, s := range tt.leaks {
+				if strings.Contains(out, s) {
+					t.Errorf("output leaks %q: %s", s, out)
+				}
+			}
+			for _, s := range tt.keeps {
+				if !strings.Contains(out, s) {
+					t.Errorf("output lost %q: %s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactLeavesCleanValues(t *testing.T) {
+	r := DefaultRedactor()
+	v := map[string]any{"a": []int{1, 2}, "b": struct{ Name string }{"x"}}
+	fields := []Field{format.Any("v", v)}
+	r.Redact(fields)
+	if reflect.ValueOf(fields[0].Any()).Pointer() != reflect.ValueOf(v).Pointer() {
+		t.Fatalf("clean value was rebuilt: %v", fields[0].Any())
+	}
+}
+
+func TestLuhn(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"4111111111111111", true},
+		{"4111 1111 1111 1111", true},
+		{"4111-1111-1111-1111", true},
+		{"378282246310005", true},
+		{"4111111111111112", false},
+		{"1700000000000", false},
+		{"411111111111", false},
+		{"4111a111111111111", false},
+	}
+	for _, tt := range tests {
+		if got := Luhn(tt.in); got != tt.want {
+			t.Errorf("Luhn(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedactCards(t *testing.T) {
+	r := DefaultRedactor()
+	for _, s := range []string{
+		"since=1700000000000",
+		"order 1234567890123",
+		"ts=1760781296123456780",
+		"card 4111 1111 1111 1112",
+	} {
+		if got := r.RedactString(s); got != s {
+			t.Errorf("RedactString(%q) = %q, want it unchanged", s, got)
+		}
+	}
+
+	got := r.RedactString("card 4111-1111-1111-1111 since=1700000000000")
+	if want := "card [REDACTED] since=1700000000000"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
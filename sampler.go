@@ -0,0 +1,185 @@
+package aurora
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type Sampler interface {
+	Sample(level Level, msg string) bool
+}
+
+type SamplerFunc func(level Level, msg string) bool
+
+func (f SamplerFunc) Sample(level Level, msg string) bool {
+	return f(level, msg)
+}
+
+const maxSamplerKeys = 4096
+
+type samplerKey struct {
+	level Level
+	msg   string
+}
+
+type samplerCounter struct {
+	resetAt time.Time
+	n       int
+}
+
+type burstSampler struct {
+	first      int
+	thereafter int
+	interval   time.Duration
+
+	mu     sync.Mutex
+	counts map[samplerKey]*samplerCounter
+}
+
+func NewBurstSampler(first, thereafter int, interval time.Duration) Sampler {
+	return &burstSampler{
+		first:      first,
+		thereafter: thereafter,
+		interval:   interval,
+		counts:     make(map[samplerKey]*samplerCounter),
+	}
+}
+
+func (s *burstSampler) Sample(level Level, msg string) bool {
+	now := time.Now()
+	key := samplerKey{level: level, msg: msg}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counts[key]
+	if !ok {
+		if len(s.counts) >= maxSamplerKeys {
+			s.sweep(now)
+		}
+		c = &samplerCounter{}
+		s.counts[key] = c
+	}
+	if !now.Before(c.resetAt) {
+		c.n = 0
+		c.resetAt = now.Add(s.interval)
+	}
+
+	c.n++
+	if c.n <= s.first {
+		return true
+	}
+	if s.thereafter <= 0 {
+		return false
+	}
+	return (c.n-s.first)%s.thereafter == 0
+}
+
+func (s *burstSampler) sweep(now time.Time) {
+	for key, c := range s.counts {
+		if !now.Before(c.resetAt) {
+			delete(s.counts, key)
+		}
+	}
+}
+
+type deduper struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	key     samplerKey
+	last    Record
+	repeats int
+	logger  *Logger
+	timer   *time.Timer
+	buf     []byte
+}
+
+func newDeduper(window time.Duration) *deduper {
+	return &deduper{window: window}
+}
+
+func (d *deduper) admit(l *Logger, r *Record) bool {
+	key := samplerKey{level: Level(r.Level.Value), msg: r.Message}
+
+	d.mu.Lock()
+	if d.logger != nil && key == d.key && r.Time.Sub(d.last.Time) < d.window && d.sameFields(r.Fields) {
+		d.repeats++
+		d.last = copyRecord(r)
+		d.logger = l
+		d.timer.Reset(d.window)
+		d.mu.Unlock()
+		return false
+	}
+
+	summary, pending := d.take()
+	d.key = key
+	d.last = copyRecord(r)
+	d.logger = l
+	if d.timer == nil {
+		d.timer = time.AfterFunc(d.window, d.expire)
+	} else {
+		d.timer.Reset(d.window)
+	}
+	d.mu.Unlock()
+
+	if pending {
+		l.dispatch(&summary)
+	}
+	return true
+}
+
+func (d *deduper) expire() {
+	d.mu.Lock()
+	summary, pending := d.take()
+	l := d.logger
+	d.logger = nil
+	d.mu.Unlock()
+
+	if pending {
+		l.mu.Lock()
+		l.dispatch(&summary)
+		l.mu.Unlock()
+	}
+}
+
+func (d *deduper) take() (Record, bool) {
+	if d.repeats == 0 {
+		return Record{}, false
+	}
+	summary := d.last
+	summary.Fields = append(summary.Fields, format.String("repeated", strconv.Itoa(d.repeats)+"×"))
+	d.repeats = 0
+	return summary, true
+}
+
+func (d *deduper) sameFields(fields []Field) bool {
+	last := d.last.Fields
+	if len(fields) != len(last) {
+		return false
+	}
+	for i, f := range fields {
+		if f.Key != last[i].Key || f.Kind != last[i].Kind {
+			return false
+		}
+		d.buf = f.AppendText(d.buf[:0])
+		n := len(d.buf)
+		d.buf = last[i].AppendText(d.buf)
+		if string(d.buf[:n]) != string(d.buf[n:]) {
+			return false
+		}
+	}
+	return true
+}
+
+func copyRecord(r *Record) Record {
+	rec := *r
+	rec.Fields = make([]Field, len(r.Fields))
+	for i, f := range r.Fields {
+		rec.Fields[i] = f.Snapshot()
+	}
+	return rec
+}
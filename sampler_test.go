@@ -0,0 +1,139 @@
+package aurora
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type lineSink struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (s *lineSink) Enabled(Level) bool { return true }
+
+func (s *lineSink) Write(r *Record) error {
+	line := format.JSON{TimeKey: "-"}.Format(nil, r)
+	s.mu.Lock()
+	s.lines = append(s.lines, strings.TrimSuffix(string(line), "\n"))
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *lineSink) Lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.lines...)
+}
+
+func TestBurstSampler(t *testing.T) {
+	s := NewBurstSampler(2, 3, time.Hour)
+	var got []int
+	for i := 1; i <= 10; i++ {
+		if s.Sample(InfoLevel, "tick") {
+			got = append(got, i)
+		}
+	}
+	if want := []int{1, 2, 5, 8}; !equalInts(got, want) {
+		t.Fatalf("sampled %v, want %v", got, want)
+	}
+	if !s.Sample(InfoLevel, "other") || !s.Sample(WarnLevel, "tick") {
+		t.Fatal("counters are shared across messages or levels")
+	}
+
+	s = NewBurstSampler(1, 0, 20*time.Millisecond)
+	if !s.Sample(InfoLevel, "tick") || s.Sample(InfoLevel, "tick") {
+		t.Fatal("thereafter 0 should keep only the first entry")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if !s.Sample(InfoLevel, "tick") {
+		t.Fatal("counter was not reset after the interval")
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSamplerSparesCriticalEntries(t *testing.T) {
+	sink := &lineSink{}
+	log := New(WithSinks(sink), WithSampler(NewBurstSampler(1, 0, time.Hour)), WithExitFunc(func(int) {}))
+	for i := 0; i < 3; i++ {
+		log.Info("tick").Send()
+	}
+	for i := 0; i < 2; i++ {
+		log.Fatal("down").Send()
+	}
+	if got := len(sink.Lines()); got != 3 {
+		t.Fatalf("wrote %d lines, want 3:\n%s", got, strings.Join(sink.Lines(), "\n"))
+	}
+}
+
+func TestDedupKeepsDistinctFields(t *testing.T) {
+	sink := &lineSink{}
+	log := New(WithSinks(sink), WithDedup(time.Hour))
+	for _, host := range []string{"h1", "h2", "h3", "h4", "h5"} {
+		log.Warn("Connection failed").Str("host", host).Send()
+	}
+	lines := sink.Lines()
+	if len(lines) != 5 {
+		t.Fatalf("wrote %d lines, want 5:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	for i, line := range lines {
+		if !strings.Contains(line, `"host":"h`+string(rune('1'+i))+`"`) || strings.Contains(line, "repeated") {
+			t.Errorf("line %d = %s", i, line)
+		}
+	}
+}
+
+func TestDedupSummary(t *testing.T) {
+	sink := &lineSink{}
+	log := New(WithSinks(sink), WithDedup(time.Hour))
+	for i := 0; i < 4; i++ {
+		log.Warn("Connection failed").Str("host", "h1").Send()
+	}
+	log.Info("recovered").Send()
+
+	want := []string{
+		`{"level":"WARN","message":"Connection failed","host":"h1"}`,
+		`{"level":"WARN","message":"Connection failed","host":"h1","repeated":"3×"}`,
+		`{"level":"INFO","message":"recovered"}`,
+	}
+	if got := sink.Lines(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDedupTimerSummary(t *testing.T) {
+	sink := &lineSink{}
+	log := New(WithSinks(sink), WithDedup(20*time.Millisecond))
+	m := map[string]int{"n": 1}
+	for i := 0; i < 3; i++ {
+		log.Warn("Connection failed").Any("m", m).Send()
+	}
+	m["n"] = 2
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(sink.Lines()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("no summary after the window: %v", sink.Lines())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	want := `{"level":"WARN","message":"Connection failed","m":{"n":1},"repeated":"2×"}`
+	if got := sink.Lines()[1]; got != want {
+		t.Fatalf("summary = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package aurora
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/Summaw/aurora/pkg/color"
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+type Sink interface {
+	Enabled(level Level) bool
+	Write(r *Record) error
+}
+
+type Flusher interface {
+	Flush() error
+}
+
+type Closer interface {
+	Close() error
+}
+
+type writerSink struct {
+	out       io.Writer
+	level     Level
+	formatter Formatter
+}
+
+func NewSink(w io.Writer, level Level, f Formatter) Sink {
+	if f == nil {
+		f = format.Pretty{}
+	}
+	if cf, ok := f.(format.ColorFormatter); ok {
+		f = cf.WithProfile(color.ProfileFor(w))
+	}
+	return &writerSink{
+		out:       w,
+		level:     level,
+		formatter: f,
+	}
+}
+
+func (s *writerSink) Enabled(level Level) bool {
+	return level >= s.level
+}
+
+func (s *writerSink) Write(r *Record) error {
+	buf := getBuffer()
+	*buf = s.formatter.Format(*buf, r)
+	_, err := s.out.Write(*buf)
+	putBuffer(buf)
+	return err
+}
+
+func (s *writerSink) Flush() error {
+	if f, ok := s.out.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func (s *writerSink) Close() error {
+	if err := s.Flush(); err != nil {
+		return err
+	}
+	if s.out == os.Stdout || s.out == os.Stderr {
+		return nil
+	}
+	if c, ok := s.out.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 1024)
+		return &buf
+	},
+}
+
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+func putBuffer(buf *[]byte) {
+	if cap(*buf) > 64<<10 {
+		return
+	}
+	*buf = (*buf)[:0]
+	bufferPool.Put(buf)
+}
@@ -0,0 +1,219 @@
+package aurora
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+const (
+	SlogLevelTrace   = slog.Level(-8)
+	SlogLevelSuccess = slog.Level(2)
+	SlogLevelFatal   = slog.Level(12)
+	SlogLevelPanic   = slog.Level(16)
+)
+
+func LevelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelDebug:
+		return TraceLevel
+	case level < slog.LevelInfo:
+		return DebugLevel
+	case level < SlogLevelSuccess:
+		return InfoLevel
+	case level < slog.LevelWarn:
+		return SuccessLevel
+	case level < slog.LevelError:
+		return WarnLevel
+	case level < SlogLevelFatal:
+		return ErrorLevel
+	case level < SlogLevelPanic:
+		return FatalLevel
+	default:
+		return PanicLevel
+	}
+}
+
+func (l Level) Slog() slog.Level {
+	switch {
+	case l < DebugLevel:
+		return SlogLevelTrace
+	case l < InfoLevel:
+		return slog.LevelDebug
+	case l < SuccessLevel:
+		return slog.LevelInfo
+	case l < WarnLevel:
+		return SlogLevelSuccess
+	case l < ErrorLevel:
+		return slog.LevelWarn
+	case l < FatalLevel:
+		return slog.LevelError
+	case l < PanicLevel:
+		return SlogLevelFatal
+	default:
+		return SlogLevelPanic
+	}
+}
+
+type slogHandler struct {
+	logger *Logger
+	goas   []groupOrAttrs
+}
+
+type groupOrAttrs struct {
+	group string
+	attrs []Field
+}
+
+func NewSlogHandler(l *Logger) slog.Handler {
+	return &slogHandler{logger: l}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.enabled(LevelFromSlog(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	entry := h.logger.newEntry(LevelFromSlog(r.Level), r.Message)
+	if entry.discard {
+		return nil
+	}
+
+	entry.Timestamp = r.Time
+	if h.logger.config.ShowCaller {
+		entry.Caller = ""
+		if r.PC != 0 {
+			frames := runtime.CallersFrames([]uintptr{r.PC})
+			frame, _ := frames.Next()
+			entry.Caller = fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+	}
+
+	fields := make([]Field, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendSlogAttr(fields, a)
+		return true
+	})
+
+	for i := len(h.goas) - 1; i >= 0; i-- {
+		goa := h.goas[i]
+		if goa.group != "" {
+			if len(fields) > 0 {
+				fields = []Field{format.Group(goa.group, fields...)}
+			}
+			continue
+		}
+		fields = append(append([]Field{}, goa.attrs...), fields...)
+	}
+
+	entry.Fields = append(entry.Fields, h.logger.contextFields(ctx)...)
+	entry.Fields = append(entry.Fields, fields...)
+	h.logger.write(entry)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	var fields []Field
+	for _, a := range attrs {
+		fields = appendSlogAttr(fields, a)
+	}
+	return h.with(groupOrAttrs{attrs: fields})
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(groupOrAttrs{group: name})
+}
+
+func (h *slogHandler) with(goa groupOrAttrs) *slogHandler {
+	goas := make([]groupOrAttrs, len(h.goas), len(h.goas)+1)
+	copy(goas, h.goas)
+	return &slogHandler{
+		logger: h.logger,
+		goas:   append(goas, goa),
+	}
+}
+
+func appendSlogAttr(fields []Field, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		var group []Field
+		for _, ga := range a.Value.Group() {
+			group = appendSlogAttr(group, ga)
+		}
+		if len(group) == 0 {
+			return fields
+		}
+		if a.Key == "" {
+			return append(fields, group...)
+		}
+		return append(fields, format.Group(a.Key, group...))
+	case slog.KindString:
+		return append(fields, format.String(a.Key, a.Value.String()))
+	case slog.KindInt64:
+		return append(fields, format.Int64(a.Key, a.Value.Int64()))
+	case slog.KindUint64:
+		return append(fields, format.Uint64(a.Key, a.Value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, format.Float64(a.Key, a.Value.Float64()))
+	case slog.KindBool:
+		return append(fields, format.Bool(a.Key, a.Value.Bool()))
+	case slog.KindDuration:
+		return append(fields, format.Duration(a.Key, a.Value.Duration()))
+	case slog.KindTime:
+		return append(fields, format.Time(a.Key, a.Value.Time()))
+	default:
+		return append(fields, format.Any(a.Key, a.Value.Any()))
+	}
+}
+
+type slogSink struct {
+	handler slog.Handler
+}
+
+func NewSlogSink(h slog.Handler) Sink {
+	return &slogSink{handler: h}
+}
+
+func (s *slogSink) Enabled(level Level) bool {
+	return s.handler.Enabled(context.Background(), level.Slog())
+}
+
+func (s *slogSink) Write(r *Record) error {
+	rec := slog.NewRecord(r.Time, Level(r.Level.Value).Slog(), r.Message, 0)
+	for _, field := range r.Fields {
+		rec.AddAttrs(slogAttr(field))
+	}
+	if r.Name != "" {
+		rec.AddAttrs(slog.String("logger", r.Name))
+	}
+	if r.Caller != "" {
+		rec.AddAttrs(slog.String("caller", r.Caller))
+	}
+	return s.handler.Handle(context.Background(), rec)
+}
+
+func slogAttr(field Field) slog.Attr {
+	if group, ok := field.Any().([]Field); ok && field.Kind == format.KindAny {
+		attrs := make([]any, len(group))
+		for i, f := range group {
+			attrs[i] = slogAttr(f)
+		}
+		return slog.Group(field.Key, attrs...)
+	}
+	return slog.Any(field.Key, field.Any())
+}
@@ -0,0 +1,137 @@
+package aurora
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+
+	"github.com/Summaw/aurora/pkg/format"
+)
+
+func TestSlogHandlerConformance(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(WithOutput(&buf), WithLevel(TraceLevel),
+		WithFormatter(format.JSON{TimeKey: "time", LevelKey: "level", MessageKey: "msg"}))
+
+	err := slogtest.TestHandler(NewSlogHandler(log), func() []map[string]any {
+		var results []map[string]any
+		for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+			m := map[string]any{}
+			if err := json.Unmarshal(line, &m); err != nil {
+				t.Fatal(err)
+			}
+			results = append(results, m)
+		}
+		return results
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSlogHandlerNesting(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(WithOutput(&buf), WithFormatter(format.JSON{TimeKey: "-"}))
+
+	slog.New(NewSlogHandler(log)).
+		With("a", 1).
+		WithGroup("g").
+		With("b", 2).
+		WithGroup("h").
+		Info("msg", "c", 3, slog.Group("d", "e", 4))
+
+	want := `{"level":"INFO","message":"msg","a":1,"g":{"b":2,"h":{"c":3,"d":{"e":4}}}}` + "\n"
+	if buf.String() != want {
+		t.Fatalf("got  %s\nwant %s", buf.String(), want)
+	}
+
+	buf.Reset()
+	slog.New(NewSlogHandler(log)).WithGroup("g").WithGroup("h").Info("empty")
+	if want := `{"level":"INFO","message":"empty"}` + "\n"; buf.String() != want {
+		t.Fatalf("got  %s\nwant %s", buf.String(), want)
+	}
+}
+
+func TestSlogLevels(t *testing.T) {
+	tests := []struct {
+		level Level
+		slog  slog.Level
+	}{
+		{TraceLevel, SlogLevelTrace},
+		{DebugLevel, slog.LevelDebug},
+		{InfoLevel, slog.LevelInfo},
+		{SuccessLevel, SlogLevelSuccess},
+		{WarnLevel, slog.LevelWarn},
+		{ErrorLevel, slog.LevelError},
+		{FatalLevel, SlogLevelFatal},
+		{PanicLevel, SlogLevelPanic},
+	}
+	for _, tt := range tests {
+		if got := tt.level.Slog(); got != tt.slog {
+			t.Errorf("%v.Slog() = %v, want %v", tt.level, got, tt.slog)
+		}
+		if got := LevelFromSlog(tt.slog); got != tt.level {
+			t.Errorf("LevelFromSlog(%v) = %v, want %v", tt.slog, got, tt.level)
+		}
+	}
+
+	var buf bytes.Buffer
+	log := New(WithOutput(&buf), WithLevel(TraceLevel), WithFormatter(format.JSON{TimeKey: "-"}),
+		WithExitFunc(func(int) { t.Fatal("slog record exited the process") }))
+	sl := slog.New(NewSlogHandler(log))
+	for _, tt := range tests {
+		buf.Reset()
+		sl.Log(context.Background(), tt.slog, "x")
+		if want := `{"level":"` + tt.level.String() + `","message":"x"}` + "\n"; buf.String() != want {
+			t.Errorf("slog level %v: got %s, want %s", tt.slog, buf.String(), want)
+		}
+	}
+}
+
+type captureHandler struct {
+	records []slog.Record
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *captureHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r)
+	return nil
+}
+
+func TestSlogSink(t *testing.T) {
+	h := &captureHandler{}
+	log := New(WithSinks(NewSlogSink(h)), WithLevel(TraceLevel), WithExitFunc(func(int) {})).Named("api")
+
+	log.Success("saved").Dict("db", func(d *Entry) { d.Str("host", "h1").Int64("port", 5432) }).Send()
+	log.Fatal("down").Send()
+	func() {
+		defer func() { recover() }()
+		log.Panic("boom").Send()
+	}()
+
+	want := []slog.Level{SlogLevelSuccess, SlogLevelFatal, SlogLevelPanic}
+	if len(h.records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(h.records), len(want))
+	}
+	for i, r := range h.records {
+		if r.Level != want[i] || LevelFromSlog(r.Level) != []Level{SuccessLevel, FatalLevel, PanicLevel}[i] {
+			t.Errorf("record %d: level %v, want %v", i, r.Level, want[i])
+		}
+	}
+
+	var attrs []string
+	h.records[0].Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a.String())
+		return true
+	})
+	if got := strings.Join(attrs, " "); got != "db=[host=h1 port=5432] logger=api" {
+		t.Fatalf("attrs = %s", got)
+	}
+}